@@ -0,0 +1,63 @@
+// Package fakesql provides a minimal database/sql driver for tests.
+package fakesql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+// Connector is a database connector whose queries return fixed rows.
+// If Err is set, it's returned by the rows' iteration after the last row.
+type Connector struct {
+	Cols []string
+	Rows [][]driver.Value
+	Err  error
+}
+
+// Connect returns a connection to the fake database.
+func (c *Connector) Connect(context.Context) (driver.Conn, error) { return conn{c}, nil }
+
+// Driver returns the fake driver.
+func (c *Connector) Driver() driver.Driver { return c }
+
+// Open returns a connection to the fake database.
+func (c *Connector) Open(string) (driver.Conn, error) { return conn{c}, nil }
+
+type conn struct{ c *Connector }
+
+func (c conn) Prepare(string) (driver.Stmt, error) { return stmt(c), nil }
+func (c conn) Close() error                        { return nil }
+func (c conn) Begin() (driver.Tx, error)           { return nil, errors.ErrUnsupported }
+
+type stmt struct{ c *Connector }
+
+func (s stmt) Close() error                               { return nil }
+func (s stmt) NumInput() int                              { return -1 }
+func (s stmt) Exec([]driver.Value) (driver.Result, error) { return nil, errors.ErrUnsupported }
+
+func (s stmt) Query([]driver.Value) (driver.Rows, error) {
+	return &rows{cols: s.c.Cols, rows: s.c.Rows, err: s.c.Err}, nil
+}
+
+type rows struct {
+	cols []string
+	rows [][]driver.Value
+	err  error
+}
+
+func (r *rows) Columns() []string { return r.cols }
+func (r *rows) Close() error      { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		if r.err != nil {
+			return r.err
+		}
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,25 @@
+package maybe
+
+// CollectChan reads the channel until it's closed and returns the values of the valid instances.
+// It blocks until the channel is closed.
+func CollectChan[T any](ch <-chan Maybe[T]) []T {
+	var r []T
+	for m := range ch {
+		if m.Valid {
+			r = append(r, m.Val)
+		}
+	}
+	return r
+}
+
+// FirstSomeChan reads the channel until it receives a valid instance and returns it.
+// It returns nothing if the channel is closed first. It blocks until either happens.
+// The remaining instances aren't read so the senders must not block on the channel forever.
+func FirstSomeChan[T any](ch <-chan Maybe[T]) Maybe[T] {
+	for m := range ch {
+		if m.Valid {
+			return m
+		}
+	}
+	return Maybe[T]{}
+}
@@ -0,0 +1,43 @@
+package maybe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectChan(t *testing.T) {
+	req := require.New(t)
+
+	ch := make(chan Maybe[int])
+	go func() {
+		defer close(ch)
+		for i := range 6 {
+			if i%2 == 0 {
+				ch <- Unit(i)
+			} else {
+				ch <- Nothing[int]()
+			}
+		}
+	}()
+	req.Equal([]int{0, 2, 4}, CollectChan(ch))
+
+	ch = make(chan Maybe[int])
+	close(ch)
+	req.Nil(CollectChan(ch))
+}
+
+func TestFirstSomeChan(t *testing.T) {
+	req := require.New(t)
+
+	ch := make(chan Maybe[int], 4)
+	ch <- Nothing[int]()
+	ch <- Unit(1)
+	ch <- Unit(2)
+	req.Equal(Unit(1), FirstSomeChan(ch))
+	req.Equal(Unit(2), <-ch)
+
+	ch <- Nothing[int]()
+	close(ch)
+	req.Equal(Nothing[int](), FirstSomeChan(ch))
+}
@@ -0,0 +1,40 @@
+package maybe
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/phomola/gomisc/serr"
+)
+
+// FromLookup returns nothing if the lookup function reports absence and the parsed value otherwise.
+func FromLookup[T any](lookup func() (string, bool), parse func(string) (T, error)) (Maybe[T], error) {
+	s, ok := lookup()
+	if !ok {
+		return Nothing[T](), nil
+	}
+	x, err := parse(s)
+	if err != nil {
+		return Nothing[T](), err
+	}
+	return Unit(x), nil
+}
+
+func envLookup(key string) func() (string, bool) {
+	return func() (string, bool) { return os.LookupEnv(key) }
+}
+
+// EnvString returns the value of an environment variable if it's set.
+func EnvString(key string) Maybe[string] {
+	m, _ := FromLookup(envLookup(key), func(s string) (string, error) { return s, nil })
+	return m
+}
+
+// EnvInt returns the integer value of an environment variable if it's set.
+func EnvInt(key string) (Maybe[int], error) {
+	m, err := FromLookup(envLookup(key), strconv.Atoi)
+	if err != nil {
+		return m, serr.Wrap("invalid environment variable", err, serr.String("key", key))
+	}
+	return m, nil
+}
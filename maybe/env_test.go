@@ -0,0 +1,29 @@
+package maybe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnv(t *testing.T) {
+	req := require.New(t)
+
+	t.Setenv("GOMISC_TEST_STRING", "abcd")
+	t.Setenv("GOMISC_TEST_INT", "1234")
+	t.Setenv("GOMISC_TEST_INVALID", "abcd")
+
+	req.Equal(Unit("abcd"), EnvString("GOMISC_TEST_STRING"))
+	req.Equal(Nothing[string](), EnvString("GOMISC_TEST_MISSING"))
+
+	m, err := EnvInt("GOMISC_TEST_INT")
+	req.NoError(err)
+	req.Equal(Unit(1234), m)
+
+	m, err = EnvInt("GOMISC_TEST_MISSING")
+	req.NoError(err)
+	req.Equal(Nothing[int](), m)
+
+	_, err = EnvInt("GOMISC_TEST_INVALID")
+	req.ErrorContains(err, "key=GOMISC_TEST_INVALID")
+}
@@ -0,0 +1,49 @@
+package maybe
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/phomola/gomisc/serr"
+)
+
+// ToJSON encodes a maybe instance to JSON, nothing is encoded as null.
+func ToJSON[T any](m Maybe[T]) ([]byte, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, serr.Wrap("failed to encode maybe", err)
+	}
+	return b, nil
+}
+
+// FromJSON decodes a maybe instance from JSON, null is decoded as nothing.
+func FromJSON[T any](data []byte) (Maybe[T], error) {
+	var m Maybe[T]
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Maybe[T]{}, serr.Wrap("failed to decode maybe", err, serr.Int("length", len(data)))
+	}
+	return m, nil
+}
+
+// DecodeArray decodes a JSON array element by element, null elements are decoded as nothing.
+func DecodeArray[T any](dec *json.Decoder) ([]Maybe[T], error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, serr.Wrap("failed to read array start", err)
+	}
+	if tok != json.Delim('[') {
+		return nil, serr.New("expected array", serr.String("token", fmt.Sprint(tok)))
+	}
+	var r []Maybe[T]
+	for i := 0; dec.More(); i++ {
+		var m Maybe[T]
+		if err := dec.Decode(&m); err != nil {
+			return nil, serr.Wrap("failed to decode array element", err, serr.Int("index", i))
+		}
+		r = append(r, m)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, serr.Wrap("failed to read array end", err)
+	}
+	return r, nil
+}
@@ -0,0 +1,55 @@
+package maybe
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON(t *testing.T) {
+	req := require.New(t)
+
+	b, err := ToJSON(Unit(12))
+	req.NoError(err)
+	req.Equal(`12`, string(b))
+	b, err = ToJSON(Nothing[int]())
+	req.NoError(err)
+	req.Equal(`null`, string(b))
+	_, err = ToJSON(Unit(func() {}))
+	req.ErrorContains(err, "failed to encode maybe")
+
+	m, err := FromJSON[int]([]byte(`12`))
+	req.NoError(err)
+	req.Equal(Unit(12), m)
+	m, err = FromJSON[int]([]byte(`null`))
+	req.NoError(err)
+	req.Equal(Nothing[int](), m)
+	_, err = FromJSON[int]([]byte(`"abc"`))
+	req.ErrorContains(err, "failed to decode maybe")
+	req.True(strings.HasSuffix(err.Error(), " length=5"))
+	_, err = FromJSON[int]([]byte(`{`))
+	req.Error(err)
+}
+
+func TestDecodeArray(t *testing.T) {
+	req := require.New(t)
+
+	l, err := DecodeArray[int](json.NewDecoder(strings.NewReader(`[1, null, 3]`)))
+	req.NoError(err)
+	req.Equal([]Maybe[int]{Unit(1), Nothing[int](), Unit(3)}, l)
+
+	l, err = DecodeArray[int](json.NewDecoder(strings.NewReader(`[]`)))
+	req.NoError(err)
+	req.Empty(l)
+
+	_, err = DecodeArray[int](json.NewDecoder(strings.NewReader(`[1, "a"]`)))
+	req.ErrorContains(err, "index=1")
+
+	_, err = DecodeArray[int](json.NewDecoder(strings.NewReader(`{}`)))
+	req.EqualError(err, "expected array token={")
+
+	_, err = DecodeArray[int](json.NewDecoder(strings.NewReader(`[1,`)))
+	req.Error(err)
+}
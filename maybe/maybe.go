@@ -14,6 +14,7 @@ import (
 
 	"github.com/fealsamh/go-utils/function"
 	"github.com/fealsamh/go-utils/nocopy"
+	"github.com/phomola/gomisc/tuple"
 )
 
 var (
@@ -27,6 +28,7 @@ type Iface interface {
 	Get() (any, bool)
 	GetPtr() unsafe.Pointer
 	SetValid()
+	SetNothing()
 	MaybeType() reflect.Type
 }
 
@@ -82,6 +84,14 @@ func (m Maybe[T]) GetOrZero() T {
 	return x
 }
 
+// MustGet returns the underlying value if valid and panics otherwise.
+func (m Maybe[T]) MustGet() T {
+	if !m.Valid {
+		panic("called MustGet on a None Maybe[" + reflect.TypeFor[T]().String() + "]")
+	}
+	return m.Val
+}
+
 // Get gets the underlying value if valid.
 func (m *Maybe[T]) Get() (any, bool) {
 	if m.Valid {
@@ -124,6 +134,13 @@ func (m *Maybe[T]) SetValid() {
 	m.Valid = true
 }
 
+// SetNothing clears the underlying value.
+func (m *Maybe[T]) SetNothing() {
+	var x T
+	m.Val = x
+	m.Valid = false
+}
+
 // Unit returns a maybe instance with an underlying value.
 func Unit[T any](x T) Maybe[T] {
 	return Maybe[T]{Val: x, Valid: true}
@@ -134,6 +151,43 @@ func Nothing[T any]() Maybe[T] {
 	return Maybe[T]{}
 }
 
+// Try calls the function and returns its result or nothing if it panics.
+// The panic is recovered and its value is discarded.
+func Try[T any](f func() T) (m Maybe[T]) {
+	defer func() {
+		if recover() != nil {
+			m = Maybe[T]{}
+		}
+	}()
+	return Maybe[T]{Val: f(), Valid: true}
+}
+
+// OfNonZero returns a maybe instance with the value or nothing if it's the zero value.
+func OfNonZero[T comparable](x T) Maybe[T] {
+	var zero T
+	if x == zero {
+		return Maybe[T]{}
+	}
+	return Maybe[T]{Val: x, Valid: true}
+}
+
+// OfNonZeroFunc returns a maybe instance with the value or nothing if the provided function reports it as zero.
+func OfNonZeroFunc[T any](isZero func(T) bool, x T) Maybe[T] {
+	if isZero(x) {
+		return Maybe[T]{}
+	}
+	return Maybe[T]{Val: x, Valid: true}
+}
+
+// FromResult returns a maybe instance with the value if the error is nil and nothing otherwise.
+// The error is intentionally dropped, e.g. in maybe.FromResult(strconv.Atoi(s)).
+func FromResult[T any](v T, err error) Maybe[T] {
+	if err != nil {
+		return Maybe[T]{}
+	}
+	return Maybe[T]{Val: v, Valid: true}
+}
+
 // Fmap is the functorial map for Maybe.
 func Fmap[T, U any](f func(T) U, x Maybe[T]) Maybe[U] {
 	if !x.Valid {
@@ -142,6 +196,11 @@ func Fmap[T, U any](f func(T) U, x Maybe[T]) Maybe[U] {
 	return Maybe[U]{Valid: true, Val: f(x.Val)}
 }
 
+// Map is equivalent to [Fmap].
+func Map[T, U any](f func(T) U, x Maybe[T]) Maybe[U] {
+	return Fmap(f, x)
+}
+
 // FallibleFmap is the functorial map for a possibly erring function.
 func FallibleFmap[T, U any](f func(T) (U, error), x Maybe[T]) (Maybe[U], error) {
 	if !x.Valid {
@@ -162,11 +221,137 @@ func Bind[T, U any](f func(T) Maybe[U], x Maybe[T]) Maybe[U] {
 	return f(x.Val)
 }
 
+// AndThen is the monadic bind operation in method form so that chains read left to right.
+// Since methods can't introduce type parameters, it's limited to functions which preserve the type,
+// [Bind] has to be used for functions changing it.
+func (m Maybe[T]) AndThen(f func(T) Maybe[T]) Maybe[T] {
+	return Bind(f, m)
+}
+
+// Traverse applies a function to each element of a slice and returns the results
+// if all of them are valid and nothing otherwise.
+func Traverse[T, U any](f func(T) Maybe[U], l []T) Maybe[[]U] {
+	r := make([]U, len(l))
+	for i, x := range l {
+		y := f(x)
+		if !y.Valid {
+			return Nothing[[]U]()
+		}
+		r[i] = y.Val
+	}
+	return Unit(r)
+}
+
+// Sequence returns the values of all instances if all of them are valid and nothing otherwise.
+func Sequence[T any](ms []Maybe[T]) Maybe[[]T] {
+	return Traverse(function.Identity, ms)
+}
+
+// Partition returns the values of the valid instances (in their order) and the indices of the invalid ones.
+func Partition[T any](ms []Maybe[T]) (present []T, noneIndices []int) {
+	for i, m := range ms {
+		if m.Valid {
+			present = append(present, m.Val)
+		} else {
+			noneIndices = append(noneIndices, i)
+		}
+	}
+	return present, noneIndices
+}
+
+// Cast returns the value asserted to be of type T or nothing if the assertion fails.
+func Cast[T any](x any) Maybe[T] {
+	if y, ok := x.(T); ok {
+		return Unit(y)
+	}
+	return Nothing[T]()
+}
+
+// CastSlice returns the elements asserted to be of type T or nothing if any of the assertions fails.
+func CastSlice[T any](l []any) Maybe[[]T] {
+	return Traverse(Cast[T], l)
+}
+
+// Chain is a builder for chains of binds started with [Start] and finished with [Chain.Result].
+// Like [Maybe.AndThen], it's limited to functions which preserve the type.
+type Chain[T any] struct {
+	m Maybe[T]
+}
+
+// Start starts a chain of binds.
+func Start[T any](m Maybe[T]) Chain[T] {
+	return Chain[T]{m: m}
+}
+
+// Then adds a bind to the chain. The function isn't called if the chain's value is already empty.
+func (c Chain[T]) Then(f func(T) Maybe[T]) Chain[T] {
+	return Chain[T]{m: Bind(f, c.m)}
+}
+
+// Result returns the chain's value.
+func (c Chain[T]) Result() Maybe[T] {
+	return c.m
+}
+
 // Join is the monadic join operation.
 func Join[T any](x Maybe[Maybe[T]]) Maybe[T] {
 	return Bind(function.Identity, x)
 }
 
+// Map2 applies a binary function to the values of two instances if both are valid.
+func Map2[A, B, C any](f func(A, B) C, a Maybe[A], b Maybe[B]) Maybe[C] {
+	if !a.Valid || !b.Valid {
+		return Maybe[C]{}
+	}
+	return Maybe[C]{Valid: true, Val: f(a.Val, b.Val)}
+}
+
+// Map3 applies a ternary function to the values of three instances if all of them are valid.
+func Map3[A, B, C, D any](f func(A, B, C) D, a Maybe[A], b Maybe[B], c Maybe[C]) Maybe[D] {
+	if !a.Valid || !b.Valid || !c.Valid {
+		return Maybe[D]{}
+	}
+	return Maybe[D]{Valid: true, Val: f(a.Val, b.Val, c.Val)}
+}
+
+// Lift2 lifts a binary function to a function on maybe instances.
+// Lift2(f)(a, b) is equivalent to Map2(f, a, b).
+func Lift2[A, B, C any](f func(A, B) C) func(Maybe[A], Maybe[B]) Maybe[C] {
+	return func(a Maybe[A], b Maybe[B]) Maybe[C] {
+		return Map2(f, a, b)
+	}
+}
+
+// Lift3 lifts a ternary function to a function on maybe instances.
+// Lift3(f)(a, b, c) is equivalent to Map3(f, a, b, c).
+func Lift3[A, B, C, D any](f func(A, B, C) D) func(Maybe[A], Maybe[B], Maybe[C]) Maybe[D] {
+	return func(a Maybe[A], b Maybe[B], c Maybe[C]) Maybe[D] {
+		return Map3(f, a, b, c)
+	}
+}
+
+// Zip pairs up the values of two instances if both are valid.
+func Zip[A, B any](a Maybe[A], b Maybe[B]) Maybe[tuple.Pair[A, B]] {
+	return Map2(tuple.MakePair[A, B], a, b)
+}
+
+// Ensure returns the provided instance if it's empty or if its value satisfies the predicate
+// and the provided error otherwise. The predicate isn't called for an empty instance.
+func Ensure[T any](m Maybe[T], pred func(T) bool, err error) (Maybe[T], error) {
+	if !m.Valid || pred(m.Val) {
+		return m, nil
+	}
+	return Nothing[T](), err
+}
+
+// IsZero reports whether the instance is empty.
+// It makes empty instances omittable in JSON using the `omitzero` option of struct field tags
+// (`omitempty` has no effect on structs). Without the option, an empty instance is marshalled as null.
+// Note that an instance whose value is the zero value of T is valid and therefore not omitted.
+func (m Maybe[T]) IsZero() bool {
+	return !m.Valid
+}
+
 func (m Maybe[T]) MarshalJSON() ([]byte, error) {
 	if !m.Valid {
 		return null, nil
@@ -174,6 +359,8 @@ func (m Maybe[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m.Val)
 }
 
+// UnmarshalJSON unmarshals null as nothing. Note that it isn't called for absent fields,
+// which therefore keep their previous value, [Tristate] can be used to tell them apart.
 func (m *Maybe[T]) UnmarshalJSON(val []byte) error {
 	if slices.Equal(val, null) {
 		return nil
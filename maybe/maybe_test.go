@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/phomola/gomisc/tuple"
 	"github.com/stretchr/testify/require"
 )
 
@@ -50,10 +52,59 @@ func TestMarshal(t *testing.T) {
 	req.Equal(Unit(1234), s.N)
 }
 
+func TestMarshalOmitZero(t *testing.T) {
+	req := require.New(t)
+
+	type s struct {
+		N Maybe[int] `json:"n,omitzero"`
+	}
+
+	b, err := json.Marshal(s{})
+	req.NoError(err)
+	req.Equal([]byte(`{}`), b)
+
+	b, err = json.Marshal(s{N: Unit(0)})
+	req.NoError(err)
+	req.Equal([]byte(`{"n":0}`), b)
+}
+
+func TestTry(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(Unit(2), Try(func() int { return 2 }))
+	req.Equal(Nothing[int](), Try(func() int { panic("boom") }))
+	req.Equal(Nothing[int](), Try(func() int {
+		var l []int
+		return l[1]
+	}))
+}
+
+func TestOfNonZero(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(Unit("abc"), OfNonZero("abc"))
+	req.Equal(Nothing[string](), OfNonZero(""))
+	req.Equal(Nothing[int](), OfNonZero(0))
+
+	isEmpty := func(l []int) bool { return len(l) == 0 }
+	req.Equal(Unit([]int{1}), OfNonZeroFunc(isEmpty, []int{1}))
+	req.Equal(Nothing[[]int](), OfNonZeroFunc(isEmpty, []int{}))
+}
+
+func TestFromResult(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(Unit(12), FromResult(strconv.Atoi("12")))
+	req.Equal(Nothing[int](), FromResult(strconv.Atoi("abc")))
+	req.Equal(Nothing[int](), FromResult(12, errors.New("some error")))
+}
+
 func TestFmap(t *testing.T) {
 	req := require.New(t)
 
 	req.Equal(Unit("1234"), Fmap(func(x int) string { return strconv.Itoa(x) }, Unit(1234)))
+	req.Equal(Unit("1234"), Map(strconv.Itoa, Unit(1234)))
+	req.Equal(Nothing[string](), Map(strconv.Itoa, Nothing[int]()))
 
 	x, err := FallibleFmap(func(x int) (string, error) { return strconv.Itoa(x), nil }, Unit(1234))
 	req.NoError(err)
@@ -63,10 +114,113 @@ func TestFmap(t *testing.T) {
 	req.Error(err)
 }
 
+func TestMap3(t *testing.T) {
+	req := require.New(t)
+
+	join := func(a, b, c string) string { return a + ", " + b + ", " + c }
+	req.Equal(Unit("street, city, country"), Map3(join, Unit("street"), Unit("city"), Unit("country")))
+	req.Equal(Nothing[string](), Map3(join, Unit("street"), Nothing[string](), Unit("country")))
+	req.Equal(Unit(3), Map2(func(a, b int) int { return a + b }, Unit(1), Unit(2)))
+	req.Equal(Nothing[int](), Map2(func(a, b int) int { panic("called") }, Nothing[int](), Unit(2)))
+}
+
+func TestLift(t *testing.T) {
+	req := require.New(t)
+
+	add := Lift2(func(a, b int) int { return a + b })
+	req.Equal(Unit(3), add(Unit(1), Unit(2)))
+	req.Equal(Nothing[int](), add(Unit(1), Nothing[int]()))
+	req.Equal(Nothing[int](), add(Nothing[int](), Unit(2)))
+
+	join := Lift3(func(a string, b int, c bool) string { return fmt.Sprint(a, b, c) })
+	req.Equal(Unit("a1 true"), join(Unit("a"), Unit(1), Unit(true)))
+	req.Equal(Nothing[string](), join(Unit("a"), Unit(1), Nothing[bool]()))
+}
+
+func TestZip(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(Unit(tuple.MakePair(1234, "abcd")), Zip(Unit(1234), Unit("abcd")))
+	req.Equal(Nothing[tuple.Pair[int, string]](), Zip(Unit(1234), Nothing[string]()))
+}
+
 func TestBind(t *testing.T) {
 	req := require.New(t)
 
 	req.Equal(Unit(1234), Join(Unit(Unit(1234))))
+
+	positive := func(x int) Maybe[int] {
+		if x > 0 {
+			return Unit(x)
+		}
+		return Nothing[int]()
+	}
+	double := func(x int) Maybe[int] { return Unit(2 * x) }
+	req.Equal(Unit(4), Unit(2).AndThen(positive).AndThen(double))
+	req.Equal(Nothing[int](), Unit(-2).AndThen(positive).AndThen(double))
+}
+
+func TestTraverse(t *testing.T) {
+	req := require.New(t)
+
+	parse := func(s string) Maybe[int] {
+		x, err := strconv.Atoi(s)
+		if err != nil {
+			return Nothing[int]()
+		}
+		return Unit(x)
+	}
+	req.Equal(Unit([]int{1, 2}), Traverse(parse, []string{"1", "2"}))
+	req.Equal(Nothing[[]int](), Traverse(parse, []string{"1", "a"}))
+	req.Equal(Unit([]int{}), Traverse(parse, nil))
+}
+
+func TestSequence(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(Unit([]int{1, 2}), Sequence([]Maybe[int]{Unit(1), Unit(2)}))
+	req.Equal(Nothing[[]int](), Sequence([]Maybe[int]{Unit(1), Nothing[int]()}))
+	req.Equal(Unit([]int{}), Sequence[int](nil))
+}
+
+func TestPartition(t *testing.T) {
+	req := require.New(t)
+
+	present, none := Partition([]Maybe[int]{Unit(1), Nothing[int](), Unit(3), Nothing[int]()})
+	req.Equal([]int{1, 3}, present)
+	req.Equal([]int{1, 3}, none)
+
+	present, none = Partition([]Maybe[int]{Unit(1)})
+	req.Equal([]int{1}, present)
+	req.Nil(none)
+
+	present, none = Partition[int](nil)
+	req.Nil(present)
+	req.Nil(none)
+}
+
+func TestCast(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(Unit(1234), Cast[int](any(1234)))
+	req.Equal(Nothing[string](), Cast[string](any(1234)))
+	req.Equal(Nothing[error](), Cast[error](nil))
+	req.Equal(Unit([]int{1, 2}), CastSlice[int]([]any{1, 2}))
+	req.Equal(Nothing[[]int](), CastSlice[int]([]any{1, "2"}))
+}
+
+func TestChain(t *testing.T) {
+	req := require.New(t)
+
+	trim := func(s string) Maybe[string] { return Unit(strings.TrimSpace(s)) }
+	nonEmpty := func(s string) Maybe[string] {
+		if s == "" {
+			return Nothing[string]()
+		}
+		return Unit(s)
+	}
+	req.Equal(Unit("ABCD"), Start(Unit(" abcd ")).Then(trim).Then(nonEmpty).Then(func(s string) Maybe[string] { return Unit(strings.ToUpper(s)) }).Result())
+	req.Equal(Nothing[string](), Start(Unit("  ")).Then(trim).Then(nonEmpty).Then(func(string) Maybe[string] { panic("called") }).Result())
 }
 
 func TestGetOr(t *testing.T) {
@@ -76,6 +230,13 @@ func TestGetOr(t *testing.T) {
 	req.Equal(5678, Nothing[int]().GetOr(5678))
 }
 
+func TestMustGet(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(1234, Unit(1234).MustGet())
+	req.PanicsWithValue("called MustGet on a None Maybe[int]", func() { Nothing[int]().MustGet() })
+}
+
 func TestNew(t *testing.T) {
 	req := require.New(t)
 
@@ -83,6 +244,33 @@ func TestNew(t *testing.T) {
 	req.Equal(Nothing[int](), New[int](nil))
 }
 
+func TestSetNothing(t *testing.T) {
+	req := require.New(t)
+
+	m := Unit(1234)
+	var iface Iface = &m
+	iface.SetNothing()
+	req.Equal(Nothing[int](), m)
+}
+
+func TestEnsure(t *testing.T) {
+	req := require.New(t)
+
+	positive := func(x int) bool { return x > 0 }
+
+	m, err := Ensure(Unit(1234), positive, errors.ErrUnsupported)
+	req.NoError(err)
+	req.Equal(Unit(1234), m)
+
+	m, err = Ensure(Unit(-1234), positive, errors.ErrUnsupported)
+	req.ErrorIs(err, errors.ErrUnsupported)
+	req.Equal(Nothing[int](), m)
+
+	m, err = Ensure(Nothing[int](), func(int) bool { panic("called") }, errors.ErrUnsupported)
+	req.NoError(err)
+	req.Equal(Nothing[int](), m)
+}
+
 func ExampleUnit() {
 	m := Unit(1234)
 	fmt.Println(m)
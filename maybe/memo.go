@@ -0,0 +1,41 @@
+package maybe
+
+import (
+	"sync"
+)
+
+// Memo caches the results of a function.
+// It's safe for concurrent use. Concurrent calls with the same uncached key may evaluate the function
+// more than once but all of them return the value which was cached first.
+// The cache is unbounded, entries are never evicted.
+type Memo[K comparable, V any] struct {
+	f     func(K) V
+	cache sync.Map
+}
+
+// NewMemo creates a new cache for the provided function.
+func NewMemo[K comparable, V any](f func(K) V) *Memo[K, V] {
+	return &Memo[K, V]{f: f}
+}
+
+// Get returns the cached value for the key, calling the function if it isn't cached yet.
+func (m *Memo[K, V]) Get(k K) V {
+	if v, ok := m.cache.Load(k); ok {
+		return v.(V)
+	}
+	v, _ := m.cache.LoadOrStore(k, m.f(k))
+	return v.(V)
+}
+
+// Lookup returns the cached value for the key or nothing if it isn't cached.
+func (m *Memo[K, V]) Lookup(k K) Maybe[V] {
+	if v, ok := m.cache.Load(k); ok {
+		return Unit(v.(V))
+	}
+	return Nothing[V]()
+}
+
+// Memoize returns a function which caches the results of the provided one (see [Memo]).
+func Memoize[K comparable, V any](f func(K) V) func(K) V {
+	return NewMemo(f).Get
+}
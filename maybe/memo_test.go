@@ -0,0 +1,25 @@
+package maybe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemo(t *testing.T) {
+	req := require.New(t)
+
+	calls := 0
+	m := NewMemo(func(x int) int {
+		calls++
+		return x * x
+	})
+	req.Equal(Nothing[int](), m.Lookup(3))
+	req.Equal(9, m.Get(3))
+	req.Equal(9, m.Get(3))
+	req.Equal(1, calls)
+	req.Equal(Unit(9), m.Lookup(3))
+
+	f := Memoize(func(s string) int { return len(s) })
+	req.Equal(4, f("abcd"))
+}
@@ -0,0 +1,23 @@
+package maybe
+
+import "reflect"
+
+// IsMaybeField reports whether the struct field is a maybe instance, i.e. whether a pointer to it implements [Iface].
+func IsMaybeField(f reflect.StructField) bool {
+	return f.Type.Kind() != reflect.Pointer && reflect.PointerTo(f.Type).Implements(IfaceType)
+}
+
+// FieldIface returns an instance of [Iface] through which the maybe instance can be read and modified.
+// The value must be addressable (e.g. a field of a struct obtained via a pointer) or a non-nil pointer to a maybe instance.
+func FieldIface(v reflect.Value) (Iface, bool) {
+	if v.Kind() == reflect.Pointer {
+		if !v.IsNil() && v.Type().Implements(IfaceType) {
+			return v.Interface().(Iface), true
+		}
+		return nil, false
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(IfaceType) {
+		return v.Addr().Interface().(Iface), true
+	}
+	return nil, false
+}
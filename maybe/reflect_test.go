@@ -0,0 +1,56 @@
+package maybe
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type record struct {
+	ID   int
+	Name Maybe[string]
+	Age  Maybe[int]
+	Ptr  *Maybe[int]
+}
+
+func TestMaybeFields(t *testing.T) {
+	req := require.New(t)
+
+	var fields []string
+	for f := range reflect.TypeFor[record]().Fields() {
+		if IsMaybeField(f) {
+			fields = append(fields, f.Name)
+		}
+	}
+	req.Equal([]string{"Name", "Age"}, fields)
+
+	r := record{Age: Unit(30)}
+	v := reflect.ValueOf(&r).Elem()
+	name, ok := FieldIface(v.FieldByName("Name"))
+	req.True(ok)
+	req.Equal(reflect.TypeFor[string](), name.MaybeType())
+	name.Set("abcd")
+	req.Equal(Unit("abcd"), r.Name)
+
+	age, ok := FieldIface(v.FieldByName("Age"))
+	req.True(ok)
+	x, ok := age.Get()
+	req.True(ok)
+	req.Equal(30, x)
+	age.SetNothing()
+	req.Equal(Nothing[int](), r.Age)
+
+	_, ok = FieldIface(v.FieldByName("ID"))
+	req.False(ok)
+	_, ok = FieldIface(v.FieldByName("Ptr"))
+	req.False(ok)
+	_, ok = FieldIface(reflect.ValueOf(r).FieldByName("Name"))
+	req.False(ok)
+
+	m := Unit(1)
+	iface, ok := FieldIface(reflect.ValueOf(&m))
+	req.True(ok)
+	iface.Set(2)
+	req.Equal(Unit(2), m)
+}
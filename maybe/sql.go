@@ -0,0 +1,38 @@
+package maybe
+
+import (
+	"database/sql"
+
+	"github.com/phomola/gomisc/serr"
+)
+
+// ScanRow scans the current row into the provided instances.
+// The number of instances must match the number of columns.
+func ScanRow(rows *sql.Rows, dests ...Iface) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if len(cols) != len(dests) {
+		return serr.New("column count mismatch", serr.Int("columns", len(cols)), serr.Int("destinations", len(dests)))
+	}
+	scanners := make([]any, len(dests))
+	for i, dest := range dests {
+		scanner, ok := dest.(sql.Scanner)
+		if !ok {
+			return serr.New("destination isn't a scanner", serr.String("column", cols[i]))
+		}
+		scanners[i] = scanner
+	}
+	return rows.Scan(scanners...)
+}
+
+// FromSQLNull converts a nullable SQL value to a Maybe instance.
+func FromSQLNull[T any](n sql.Null[T]) Maybe[T] {
+	return Maybe[T]{Val: n.V, Valid: n.Valid}
+}
+
+// ToSQLNull converts the instance to a nullable SQL value.
+func (m Maybe[T]) ToSQLNull() sql.Null[T] {
+	return sql.Null[T]{V: m.Val, Valid: m.Valid}
+}
@@ -0,0 +1,102 @@
+package maybe
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phomola/gomisc/internal/fakesql"
+	"github.com/stretchr/testify/require"
+)
+
+func openFake(t *testing.T, cols []string, rows ...[]driver.Value) *sql.DB {
+	db := sql.OpenDB(&fakesql.Connector{Cols: cols, Rows: rows})
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestScanRow(t *testing.T) {
+	req := require.New(t)
+
+	db := openFake(t, []string{"name", "age"}, []driver.Value{"abcd", nil}, []driver.Value{nil, int64(42)})
+	rows, err := db.Query("select")
+	req.NoError(err)
+	defer rows.Close()
+
+	var name Maybe[string]
+	var age Maybe[int]
+
+	req.True(rows.Next())
+	req.NoError(ScanRow(rows, &name, &age))
+	req.Equal(Unit("abcd"), name)
+	req.Equal(Nothing[int](), age)
+
+	req.True(rows.Next())
+	req.NoError(ScanRow(rows, &name, &age))
+	req.Equal(Nothing[string](), name)
+	req.Equal(Unit(42), age)
+
+	err = ScanRow(rows, &name)
+	req.EqualError(err, "column count mismatch columns=2 destinations=1")
+}
+
+func TestRoundTrip(t *testing.T) {
+	created := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	id := uuid.New()
+
+	t.Run("valid", func(t *testing.T) {
+		req := require.New(t)
+
+		v1, err := Unit(created).Value()
+		req.NoError(err)
+		req.Equal(created, v1)
+		v2, err := Unit(id).Value()
+		req.NoError(err)
+		req.Equal(id.String(), v2)
+
+		db := openFake(t, []string{"created", "id"}, []driver.Value{v1, v2})
+		var m1 Maybe[time.Time]
+		var m2 Maybe[uuid.UUID]
+		req.NoError(db.QueryRow("select").Scan(&m1, &m2))
+		req.Equal(Unit(created), m1)
+		req.Equal(Unit(id), m2)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		req := require.New(t)
+
+		v1, err := Nothing[time.Time]().Value()
+		req.NoError(err)
+		req.Nil(v1)
+		v2, err := Nothing[uuid.UUID]().Value()
+		req.NoError(err)
+		req.Nil(v2)
+
+		db := openFake(t, []string{"created", "id"}, []driver.Value{v1, v2})
+		m1, m2 := Unit(time.Now()), Unit(uuid.New())
+		req.NoError(db.QueryRow("select").Scan(&m1, &m2))
+		req.Equal(Nothing[time.Time](), m1)
+		req.Equal(Nothing[uuid.UUID](), m2)
+	})
+
+	t.Run("uuid from bytes and string", func(t *testing.T) {
+		req := require.New(t)
+
+		db := openFake(t, []string{"a", "b"}, []driver.Value{[]byte(id.String()), id.String()})
+		var m1, m2 Maybe[uuid.UUID]
+		req.NoError(db.QueryRow("select").Scan(&m1, &m2))
+		req.Equal(Unit(id), m1)
+		req.Equal(Unit(id), m2)
+	})
+}
+
+func TestSQLNull(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(Unit(1234), FromSQLNull(sql.Null[int]{V: 1234, Valid: true}))
+	req.Equal(Nothing[int](), FromSQLNull(sql.Null[int]{}))
+	req.Equal(sql.Null[int]{V: 1234, Valid: true}, Unit(1234).ToSQLNull())
+	req.Equal(sql.Null[int]{}, Nothing[int]().ToSQLNull())
+}
@@ -0,0 +1,77 @@
+package maybe
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// Tristate is an optional value which distinguishes an absent JSON field from an explicit null.
+// This is needed for JSON merge patch semantics where an absent field is left unchanged,
+// null deletes the value and any other value replaces it.
+// Since encoding/json doesn't call UnmarshalJSON for absent fields, an absent field keeps the zero value,
+// which is the absent state. The `omitzero` option of struct field tags makes marshalling symmetric,
+// i.e. absent instances are omitted, otherwise they're marshalled as null.
+type Tristate[T any] struct {
+	Val     T
+	Valid   bool
+	Present bool
+}
+
+// Absent returns an absent instance.
+func Absent[T any]() Tristate[T] {
+	return Tristate[T]{}
+}
+
+// Null returns an instance which is present but null.
+func Null[T any]() Tristate[T] {
+	return Tristate[T]{Present: true}
+}
+
+// Present returns an instance with a value.
+func Present[T any](x T) Tristate[T] {
+	return Tristate[T]{Val: x, Valid: true, Present: true}
+}
+
+// IsAbsent reports whether the instance is absent.
+func (t Tristate[T]) IsAbsent() bool {
+	return !t.Present
+}
+
+// IsNull reports whether the instance is present but null.
+func (t Tristate[T]) IsNull() bool {
+	return t.Present && !t.Valid
+}
+
+// Maybe returns the value if there's one and nothing otherwise.
+func (t Tristate[T]) Maybe() Maybe[T] {
+	return Maybe[T]{Val: t.Val, Valid: t.Valid}
+}
+
+// IsZero reports whether the instance is absent (see [Tristate]).
+func (t Tristate[T]) IsZero() bool {
+	return !t.Present
+}
+
+func (t Tristate[T]) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return null, nil
+	}
+	return json.Marshal(t.Val)
+}
+
+func (t *Tristate[T]) UnmarshalJSON(val []byte) error {
+	t.Present = true
+	if slices.Equal(val, null) {
+		var x T
+		t.Val = x
+		t.Valid = false
+		return nil
+	}
+	t.Valid = true
+	return json.Unmarshal(val, &t.Val)
+}
+
+var (
+	_ json.Marshaler   = Present(0)
+	_ json.Unmarshaler = (*Tristate[int])(nil)
+)
@@ -0,0 +1,38 @@
+package maybe
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type patch struct {
+	Name Tristate[string] `json:"name,omitzero"`
+	Age  Tristate[int]    `json:"age,omitzero"`
+}
+
+func TestTristate(t *testing.T) {
+	req := require.New(t)
+
+	var p patch
+	req.NoError(json.Unmarshal([]byte(`{"name":null}`), &p))
+	req.Equal(Null[string](), p.Name)
+	req.True(p.Name.IsNull())
+	req.Equal(Absent[int](), p.Age)
+	req.True(p.Age.IsAbsent())
+
+	p = patch{}
+	req.NoError(json.Unmarshal([]byte(`{"age":42}`), &p))
+	req.Equal(Absent[string](), p.Name)
+	req.Equal(Present(42), p.Age)
+	req.Equal(Unit(42), p.Age.Maybe())
+
+	b, err := json.Marshal(patch{Name: Null[string](), Age: Present(42)})
+	req.NoError(err)
+	req.Equal(`{"name":null,"age":42}`, string(b))
+
+	b, err = json.Marshal(patch{})
+	req.NoError(err)
+	req.Equal(`{}`, string(b))
+}
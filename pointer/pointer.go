@@ -0,0 +1,53 @@
+// Package pointer provides functions for optional values represented as pointers.
+package pointer
+
+// Map applies a function to the value a pointer points to.
+// It returns nil for a nil pointer.
+func Map[T, U any](p *T, f func(T) U) *U {
+	if p == nil {
+		return nil
+	}
+	r := f(*p)
+	return &r
+}
+
+// FallibleMap applies a possibly erring function to the value a pointer points to.
+// It returns nil for a nil pointer.
+func FallibleMap[T, U any](p *T, f func(T) (U, error)) (*U, error) {
+	if p == nil {
+		return nil, nil
+	}
+	r, err := f(*p)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// OrZero returns the value a pointer points to or the zero value for a nil pointer.
+func OrZero[T any](p *T) T {
+	if p == nil {
+		var x T
+		return x
+	}
+	return *p
+}
+
+// NonZero returns a pointer to the value or nil if it's the zero value.
+// The type must be comparable in order to compare the value to the zero value,
+// [NonZeroFunc] can be used for other types.
+func NonZero[T comparable](x T) *T {
+	var zero T
+	if x == zero {
+		return nil
+	}
+	return &x
+}
+
+// NonZeroFunc returns a pointer to the value or nil if the provided function reports it as zero.
+func NonZeroFunc[T any](isZero func(T) bool, x T) *T {
+	if isZero(x) {
+		return nil
+	}
+	return &x
+}
@@ -0,0 +1,43 @@
+package pointer
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMap(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(new("1234"), Map(new(1234), strconv.Itoa))
+	req.Nil(Map(nil, strconv.Itoa))
+
+	x, err := FallibleMap(new("1234"), strconv.Atoi)
+	req.NoError(err)
+	req.Equal(new(1234), x)
+
+	x, err = FallibleMap(new("abcd"), strconv.Atoi)
+	req.Error(err)
+	req.Nil(x)
+
+	x, err = FallibleMap(nil, strconv.Atoi)
+	req.NoError(err)
+	req.Nil(x)
+}
+
+func TestOrZero(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(1234, OrZero(new(1234)))
+	req.Equal(0, OrZero[int](nil))
+}
+
+func TestNonZero(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(new("abcd"), NonZero("abcd"))
+	req.Nil(NonZero(""))
+	req.Nil(NonZeroFunc(func(l []int) bool { return len(l) == 0 }, []int{}))
+	req.Equal(&[]int{1}, NonZeroFunc(func(l []int) bool { return len(l) == 0 }, []int{1}))
+}
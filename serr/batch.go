@@ -0,0 +1,143 @@
+package serr
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type batchRecord struct {
+	ctx context.Context
+	rec slog.Record
+}
+
+// BatchLogger accumulates structured errors and passes them on to a logger in batches.
+// Records are flushed in the background when the batch is full, periodically and when the logger is closed.
+// If records accumulate faster than they can be flushed, the logging goroutine flushes them itself.
+// The order of records is preserved and each record keeps the time at which it was logged.
+type BatchLogger struct {
+	logger   *slog.Logger
+	maxBatch int
+	mu       sync.Mutex
+	flushMu  sync.Mutex
+	batch    []batchRecord
+	closed   bool
+	full     chan struct{}
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewBatchLogger creates a new batch logger.
+// If flushEvery isn't positive, records are only flushed when the batch is full or on [BatchLogger.Close].
+func NewBatchLogger(logger *slog.Logger, flushEvery time.Duration, maxBatch int) *BatchLogger {
+	bl := &BatchLogger{
+		logger:   logger,
+		maxBatch: max(maxBatch, 1),
+		full:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	bl.wg.Add(1)
+	go bl.run(flushEvery)
+	return bl
+}
+
+func (bl *BatchLogger) run(flushEvery time.Duration) {
+	defer bl.wg.Done()
+	var tick <-chan time.Time
+	if flushEvery > 0 {
+		ticker := time.NewTicker(flushEvery)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-tick:
+			bl.Flush()
+		case <-bl.full:
+			bl.Flush()
+		case <-bl.done:
+			return
+		}
+	}
+}
+
+// LogDebug logs a structured error at the debug level.
+func (bl *BatchLogger) LogDebug(ctx context.Context, err error) {
+	bl.Log(ctx, slog.LevelDebug, err)
+}
+
+// LogInfo logs a structured error at the info level.
+func (bl *BatchLogger) LogInfo(ctx context.Context, err error) {
+	bl.Log(ctx, slog.LevelInfo, err)
+}
+
+// LogWarn logs a structured error at the warn level.
+func (bl *BatchLogger) LogWarn(ctx context.Context, err error) {
+	bl.Log(ctx, slog.LevelWarn, err)
+}
+
+// LogError logs a structured error at the error level.
+func (bl *BatchLogger) LogError(ctx context.Context, err error) {
+	bl.Log(ctx, slog.LevelError, err)
+}
+
+// Log logs a structured error at the provided level.
+// Once the logger has been closed, errors are flushed immediately (after any records still pending).
+func (bl *BatchLogger) Log(ctx context.Context, level slog.Level, err error) {
+	callHook(HookOnLog, err)
+	if !bl.logger.Enabled(ctx, level) {
+		return
+	}
+	msg, attrs := logRecord(err)
+	rec := slog.NewRecord(time.Now(), level, msg, 0)
+	rec.Add(attrs...)
+	rec.Add(logContextAttrs(ctx, err)...)
+
+	bl.mu.Lock()
+	bl.batch = append(bl.batch, batchRecord{ctx: ctx, rec: rec})
+	n := len(bl.batch)
+	closed := bl.closed
+	bl.mu.Unlock()
+
+	switch {
+	case closed || n >= 2*bl.maxBatch:
+		bl.Flush()
+	case n >= bl.maxBatch:
+		select {
+		case bl.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Flush passes all accumulated records on to the underlying logger.
+func (bl *BatchLogger) Flush() {
+	bl.flushMu.Lock()
+	defer bl.flushMu.Unlock()
+
+	bl.mu.Lock()
+	batch := bl.batch
+	bl.batch = nil
+	bl.mu.Unlock()
+
+	h := bl.logger.Handler()
+	for _, r := range batch {
+		h.Handle(r.ctx, r.rec)
+	}
+}
+
+// Close stops the periodic flushing and flushes all accumulated records.
+func (bl *BatchLogger) Close() {
+	bl.mu.Lock()
+	if bl.closed {
+		bl.mu.Unlock()
+		return
+	}
+	bl.closed = true
+	bl.mu.Unlock()
+
+	close(bl.done)
+	bl.wg.Wait()
+	bl.Flush()
+}
@@ -0,0 +1,98 @@
+package serr
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchLogger(t *testing.T) {
+	t.Run("max batch", func(t *testing.T) {
+		req := require.New(t)
+
+		var buf bytes.Buffer
+		bl := NewBatchLogger(slog.New(slog.NewJSONHandler(&buf, nil)), 0, 2)
+
+		bl.LogError(context.Background(), New("first", Int("n", 1)))
+		bl.LogWarn(context.Background(), New("second", Int("n", 2)))
+		bl.LogWarn(context.Background(), New("third", Int("n", 3)))
+		bl.LogWarn(context.Background(), New("fourth", Int("n", 4)))
+		bl.Close()
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		req.Len(lines, 4)
+		req.Contains(lines[0], `"level":"ERROR","msg":"first","n":1`)
+		req.Contains(lines[1], `"level":"WARN","msg":"second","n":2`)
+		req.Contains(lines[3], `"level":"WARN","msg":"fourth","n":4`)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		req := require.New(t)
+
+		var buf bytes.Buffer
+		bl := NewBatchLogger(slog.New(slog.NewJSONHandler(&buf, nil)), time.Hour, 100)
+		bl.LogError(context.Background(), New("pending"))
+		req.Empty(buf.String())
+		bl.Close()
+		req.Contains(buf.String(), `"msg":"pending"`)
+
+		buf.Reset()
+		bl.LogError(context.Background(), New("after close"))
+		req.Contains(buf.String(), `"msg":"after close"`)
+	})
+
+	t.Run("order after close", func(t *testing.T) {
+		req := require.New(t)
+
+		var buf bytes.Buffer
+		bl := NewBatchLogger(slog.New(slog.NewJSONHandler(&buf, nil)), 0, 100)
+		bl.LogError(context.Background(), New("first"))
+		bl.LogError(context.Background(), New("second"))
+		// simulates a record logged after Close marked the logger as closed but before its final flush
+		bl.mu.Lock()
+		bl.closed = true
+		bl.mu.Unlock()
+		bl.LogError(context.Background(), New("third"))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		req.Len(lines, 3)
+		req.Contains(lines[0], `"msg":"first"`)
+		req.Contains(lines[1], `"msg":"second"`)
+		req.Contains(lines[2], `"msg":"third"`)
+		close(bl.done)
+		bl.wg.Wait()
+	})
+
+	t.Run("level", func(t *testing.T) {
+		req := require.New(t)
+
+		var buf bytes.Buffer
+		bl := NewBatchLogger(slog.New(slog.NewJSONHandler(&buf, nil)), 0, 1)
+		bl.LogDebug(context.Background(), New("debug"))
+		bl.Close()
+		req.Empty(buf.String())
+	})
+}
+
+func BenchmarkDirectLogging(b *testing.B) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	err := New("msg", String("a", "1"), Int("b", 2))
+	ctx := context.Background()
+	for b.Loop() {
+		LogError(ctx, logger, err)
+	}
+}
+
+func BenchmarkBatchLogging(b *testing.B) {
+	bl := NewBatchLogger(slog.New(slog.NewJSONHandler(io.Discard, nil)), time.Second, 256)
+	defer bl.Close()
+	err := New("msg", String("a", "1"), Int("b", 2))
+	ctx := context.Background()
+	for b.Loop() {
+		bl.LogError(ctx, err)
+	}
+}
@@ -0,0 +1,33 @@
+package serr
+
+import "strconv"
+
+// Bytes is a byte count-valued attribute. The count is rendered in binary (IEC) units,
+// e.g. "1.5 MiB" for 1572864 bytes, in error messages and as a raw int64 in structured logs.
+func Bytes(key string, n int64) Attr { return Attr{key: key, value: byteSize(n)} }
+
+type byteSize int64
+
+var byteUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// LogString returns the human-readable representation of the byte count.
+func (n byteSize) LogString() string {
+	if n > -1024 && n < 1024 {
+		return strconv.FormatInt(int64(n), 10) + " B"
+	}
+	v, unit := float64(n)/1024, 0
+	s := strconv.FormatFloat(v, 'f', 1, 64)
+	// the unit is chosen based on the rounded value so that e.g. 1048575 is rendered as 1 MiB and not 1024 KiB
+	for unit < len(byteUnits)-1 {
+		if r, _ := strconv.ParseFloat(s, 64); r > -1024 && r < 1024 {
+			break
+		}
+		v /= 1024
+		unit++
+		s = strconv.FormatFloat(v, 'f', 1, 64)
+	}
+	if len(s) > 2 && s[len(s)-2:] == ".0" {
+		s = s[:len(s)-2]
+	}
+	return s + " " + byteUnits[unit]
+}
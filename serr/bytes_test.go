@@ -0,0 +1,41 @@
+package serr
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBytes(t *testing.T) {
+	req := require.New(t)
+
+	for n, s := range map[int64]string{
+		0:             "0 B",
+		1023:          "1023 B",
+		1024:          "1 KiB",
+		1536:          "1.5 KiB",
+		1572864:       "1.5 MiB",
+		5 << 30:       "5 GiB",
+		-2048:         "-2 KiB",
+		1<<63 - 1:     "8 EiB",
+		3 * (1 << 40): "3 TiB",
+		1048575:       "1 MiB",
+		1048524:       "1023.9 KiB",
+		1048525:       "1 MiB",
+		-1048575:      "-1 MiB",
+		1<<30 - 1:     "1 GiB",
+		1<<40 - 1:     "1 TiB",
+	} {
+		req.Equal(s, byteSize(n).LogString())
+	}
+
+	err := New("file too large", Bytes("size", 1572864), Bytes("limit", 1<<20))
+	req.Equal("file too large size=1.5 MiB limit=1 MiB", err.Error())
+
+	var buf bytes.Buffer
+	LogError(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)), err)
+	req.Contains(buf.String(), `"msg":"file too large","size":1572864,"limit":1048576`)
+}
@@ -0,0 +1,27 @@
+package serr
+
+import "log/slog"
+
+// Cause is an attribute carrying a related error which isn't wrapped, i.e. it's not part of the error's chain
+// and [errors.Is] and [errors.As] don't see it. It's rendered as key={message attributes...} in the error's message
+// and logged as a group named key containing the related error's message under the key "message" and its attributes.
+func Cause(key string, err error) Attr {
+	if err == nil {
+		return Attr{key: key}
+	}
+	return Attr{key: key, value: cause{err: err}}
+}
+
+type cause struct {
+	err error
+}
+
+// LogString returns the related error's message enclosed in braces.
+func (c cause) LogString() string {
+	return "{" + c.err.Error() + "}"
+}
+
+func (c cause) group(key string) slog.Attr {
+	msg, attrs := logRecord(c.err)
+	return slog.Group(key, append([]any{slog.String("message", msg)}, attrs...)...)
+}
@@ -0,0 +1,29 @@
+package serr
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCause(t *testing.T) {
+	req := require.New(t)
+
+	rollback := Wrap("rollback failed", sql.ErrConnDone, String("tx", "t1"))
+	err := Wrap("insert failed", sql.ErrNoRows, String("table", "users"), Cause("secondary", rollback))
+	req.Equal("insert failed: sql: no rows in result set table=users secondary={rollback failed: sql: connection is already closed tx=t1}", err.Error())
+	req.True(errors.Is(err, sql.ErrNoRows))
+	req.False(errors.Is(err, sql.ErrConnDone))
+
+	var buf bytes.Buffer
+	LogError(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)), err)
+	req.Contains(buf.String(), `"table":"users","secondary":{"message":"rollback failed: sql: connection is already closed","tx":"t1"}`)
+
+	err = New("msg", Cause("other", nil))
+	req.Equal("msg other=null", err.Error())
+}
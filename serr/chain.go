@@ -0,0 +1,197 @@
+package serr
+
+import (
+	"reflect"
+)
+
+// RootCause returns the innermost error of the chain of wrapped errors.
+// An error wrapping multiple errors (such as one created with [WrapMulti]) has no single cause
+// and is therefore returned unchanged.
+func RootCause(err error) error {
+	for {
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return err
+		}
+		next := u.Unwrap()
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
+
+// Chain returns the chain of wrapped errors from the outermost one to the root cause.
+func Chain(err error) []error {
+	if err == nil {
+		return nil
+	}
+	var r []error
+	for {
+		r = append(r, err)
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return r
+		}
+		next := u.Unwrap()
+		if next == nil {
+			return r
+		}
+		err = next
+	}
+}
+
+// HasAttr reports whether any error in the tree of wrapped errors carries an attribute
+// with the provided key and value. Comparable values are compared using ==,
+// other values are compared using [reflect.DeepEqual].
+func HasAttr(err error, key string, value any) bool {
+	found := false
+	walk(err, func(err error) bool {
+		for _, attr := range errAttrs(err) {
+			for _, attr := range attr.Attributes() {
+				if attr.key == key && attrValueEqual(attr.value, value) {
+					found = true
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// AllAttributes returns the attributes carried by all errors in the tree of wrapped errors in pre-order.
+func AllAttributes(err error) []Attr {
+	var attrs []Attr
+	walk(err, func(err error) bool {
+		for _, attr := range errAttrs(err) {
+			attrs = append(attrs, attr.Attributes()...)
+		}
+		return true
+	})
+	return attrs
+}
+
+func attrValueEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if reflect.ValueOf(a).Comparable() && reflect.ValueOf(b).Comparable() {
+		return a == b
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// errAttrs returns the attributes carried directly by the error (not by the errors it wraps).
+func errAttrs(err error) []Attributed {
+	switch err := err.(type) {
+	case *serror:
+		return err.attrs
+	case *wrapped:
+		return err.attrs
+	case *wrappedMulti:
+		return err.attrs
+	case *problem:
+		return err.attrs
+	case *masked:
+		return err.attrs
+	case Attributed:
+		return []Attributed{err}
+	}
+	return nil
+}
+
+// walk calls f on every error in the tree of wrapped errors in pre-order until f returns false.
+// The causes of errors created with [Mask] aren't visited.
+func walk(err error, f func(error) bool) bool {
+	if err == nil {
+		return true
+	}
+	if !f(err) {
+		return false
+	}
+	if _, ok := err.(*masked); ok {
+		return true
+	}
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		return walk(u.Unwrap(), f)
+	case interface{ Unwrap() []error }:
+		for _, err := range u.Unwrap() {
+			if !walk(err, f) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equal reports whether two errors are semantically equal, which is useful in tests.
+// Structured errors are equal if they're of the same kind, their messages are equal,
+// they carry the same attributes regardless of order and the errors they wrap are equal.
+// Masked errors (see [Mask]) are compared like wrapping structured errors, i.e. including their causes.
+// Errors with codes (see [WithCode]) are equal if their codes and the errors they wrap are equal.
+// Problem details (see [Problem]) are equal if their types, titles, statuses and attributes are equal.
+// Attribute values are compared like in [HasAttr]. Errors of other types are compared by their Error() strings.
+// No other metadata is compared.
+func Equal(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	switch a := a.(type) {
+	case *serror:
+		b, ok := b.(*serror)
+		return ok && a.msg == b.msg && attrsEqual(a.attrs, b.attrs)
+	case *wrapped:
+		b, ok := b.(*wrapped)
+		return ok && a.msg == b.msg && attrsEqual(a.attrs, b.attrs) && Equal(a.err, b.err)
+	case *wrappedMulti:
+		b, ok := b.(*wrappedMulti)
+		if !ok || a.msg != b.msg || len(a.errs) != len(b.errs) || !attrsEqual(a.attrs, b.attrs) {
+			return false
+		}
+		for i := range a.errs {
+			if !Equal(a.errs[i], b.errs[i]) {
+				return false
+			}
+		}
+		return true
+	case *coded:
+		b, ok := b.(*coded)
+		return ok && a.code == b.code && Equal(a.err, b.err)
+	case *masked:
+		b, ok := b.(*masked)
+		return ok && a.msg == b.msg && attrsEqual(a.attrs, b.attrs) && Equal(a.err, b.err)
+	case *problem:
+		b, ok := b.(*problem)
+		return ok && a.typ == b.typ && a.title == b.title && a.status == b.status && attrsEqual(a.attrs, b.attrs)
+	}
+	return a.Error() == b.Error()
+}
+
+func attrsEqual(a, b []Attributed) bool {
+	attrsA, attrsB := flattenAttrs(a), flattenAttrs(b)
+	if len(attrsA) != len(attrsB) {
+		return false
+	}
+	used := make([]bool, len(attrsB))
+outer:
+	for _, x := range attrsA {
+		for i, y := range attrsB {
+			if !used[i] && x.key == y.key && attrValueEqual(x.value, y.value) {
+				used[i] = true
+				continue outer
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func flattenAttrs(attrs []Attributed) []Attr {
+	var r []Attr
+	for _, attr := range attrs {
+		r = append(r, attr.Attributes()...)
+	}
+	return r
+}
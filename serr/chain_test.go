@@ -0,0 +1,98 @@
+package serr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootCause(t *testing.T) {
+	req := require.New(t)
+
+	root := errors.New("root")
+	inner := Wrap("inner", root)
+	outer := fmt.Errorf("outer: %w", inner)
+	req.Equal(root, RootCause(outer))
+	req.Equal(root, RootCause(root))
+	req.Equal([]error{outer, inner, root}, Chain(outer))
+	req.Nil(Chain(nil))
+
+	multi := WrapMulti("multi", []error{root, inner})
+	req.Equal(multi, RootCause(Wrap("outer", multi)))
+}
+
+func TestHasAttr(t *testing.T) {
+	req := require.New(t)
+
+	inner := New("inner", String("code", "E42"), Any("tags", []string{"a", "b"}))
+	err := fmt.Errorf("outer: %w", Wrap("wrapped", inner, Int("n", 1)))
+	req.True(HasAttr(err, "code", "E42"))
+	req.True(HasAttr(err, "n", 1))
+	req.True(HasAttr(err, "tags", []string{"a", "b"}))
+	req.False(HasAttr(err, "code", "E43"))
+	req.False(HasAttr(err, "n", "1"))
+
+	multi := WrapMulti("multi", []error{errors.New("plain"), inner})
+	req.True(HasAttr(multi, "code", "E42"))
+	req.True(HasAttr(thirdPartyError{}, "vendor", "acme"))
+	req.False(HasAttr(nil, "code", "E42"))
+
+	err = New("x", Any("k", withIface{V: []int{1}}))
+	req.True(HasAttr(err, "k", withIface{V: []int{1}}))
+	req.False(HasAttr(err, "k", withIface{V: []int{2}}))
+	req.False(HasAttr(err, "k", withIface{V: 1}))
+}
+
+type withIface struct {
+	V any
+}
+
+func TestAllAttributes(t *testing.T) {
+	req := require.New(t)
+
+	err := Wrap("outer", New("inner", Int("b", 2)), String("a", "1"))
+	req.Equal([]Attr{String("a", "1"), Int("b", 2)}, AllAttributes(err))
+	req.Nil(AllAttributes(errors.New("plain")))
+}
+
+func TestEqual(t *testing.T) {
+	req := require.New(t)
+
+	ErrSome := errors.New("some error")
+
+	req.True(Equal(
+		Wrap("msg", New("inner", Int("n", 1)), String("a", "1"), String("b", "2")),
+		Wrap("msg", New("inner", Int("n", 1)), String("b", "2"), String("a", "1")),
+	))
+	req.False(Equal(
+		Wrap("msg", New("inner", Int("n", 1)), String("a", "1")),
+		Wrap("msg", New("inner", Int("n", 2)), String("a", "1")),
+	))
+	req.False(Equal(New("msg", String("a", "1")), New("msg", String("a", "1"), String("a", "1"))))
+	req.False(Equal(New("msg"), Wrap("msg", ErrSome)))
+	req.True(Equal(WrapMulti("multi", []error{ErrSome, New("x")}), WrapMulti("multi", []error{ErrSome, New("x")})))
+	req.False(Equal(WrapMulti("multi", []error{ErrSome, New("x")}), WrapMulti("multi", []error{New("x"), ErrSome})))
+	req.True(Equal(New("x", Any("k", withIface{V: []int{1}})), New("x", Any("k", withIface{V: []int{1}}))))
+	req.False(Equal(New("x", Any("k", withIface{V: []int{1}})), New("x", Any("k", withIface{V: []int{2}}))))
+	req.True(Equal(nil, nil))
+	req.False(Equal(ErrSome, nil))
+
+	req.True(Equal(Problem("t", "title", 404, String("a", "1")), Problem("t", "title", 404, String("a", "1"))))
+	req.False(Equal(Problem("t", "title", 404), Problem("t", "title", 400)))
+	req.False(Equal(Problem("t", "title", 404), Problem("u", "title", 404)))
+	req.False(Equal(Problem("t", "title", 404, String("a", "1")), Problem("t", "title", 404, String("a", "2"))))
+	req.False(Equal(Problem("t", "title", 404), New("title")))
+
+	req.True(Equal(WithCode(New("a"), CodeNotFound), WithCode(New("a"), CodeNotFound)))
+	req.False(Equal(WithCode(New("a"), CodeNotFound), WithCode(New("a"), CodeInternal)))
+	req.False(Equal(WithCode(New("a"), CodeNotFound), WithCode(New("b"), CodeNotFound)))
+	req.False(Equal(WithCode(New("a"), CodeNotFound), New("a")))
+
+	req.True(Equal(Mask("public", New("inner", Int("n", 1)), String("a", "1")), Mask("public", New("inner", Int("n", 1)), String("a", "1"))))
+	req.False(Equal(Mask("public", New("inner", Int("n", 1))), Mask("public", New("inner", Int("n", 2)))))
+	req.False(Equal(Mask("public", ErrSome), Mask("other", ErrSome)))
+	req.False(Equal(Mask("public", ErrSome, String("a", "1")), Mask("public", ErrSome, String("a", "2"))))
+	req.False(Equal(Mask("public", ErrSome), New("public")))
+}
@@ -0,0 +1,109 @@
+package serr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Code is a transport-agnostic error code.
+type Code string
+
+// predefined error codes
+const (
+	CodeNotFound        Code = "not_found"
+	CodeInvalidArgument Code = "invalid_argument"
+	CodePermission      Code = "permission"
+	CodeInternal        Code = "internal"
+)
+
+var (
+	codeToGRPC = map[Code]codes.Code{
+		CodeNotFound:        codes.NotFound,
+		CodeInvalidArgument: codes.InvalidArgument,
+		CodePermission:      codes.PermissionDenied,
+		CodeInternal:        codes.Internal,
+	}
+	codeToHTTP = map[Code]int{
+		CodeNotFound:        http.StatusNotFound,
+		CodeInvalidArgument: http.StatusBadRequest,
+		CodePermission:      http.StatusForbidden,
+		CodeInternal:        http.StatusInternalServerError,
+	}
+)
+
+type coded struct {
+	err  error
+	code Code
+}
+
+func (se *coded) Error() string {
+	return se.err.Error()
+}
+
+// LogString returns the wrapped error's message.
+func (se *coded) LogString() string {
+	return se.err.Error()
+}
+
+func (se *coded) Unwrap() error {
+	return se.err
+}
+
+// WithCode returns an error which wraps the provided one and carries the code.
+// [ToGRPC] and [ToHTTP] use the code in preference to classifying the error. It returns nil for a nil error.
+func WithCode(err error, code Code) error {
+	if err == nil {
+		return nil
+	}
+	cerr := &coded{err: err, code: code}
+	callHook(HookOnCreate, cerr)
+	return cerr
+}
+
+// CodeOf returns the code of the first error in the chain which carries one.
+// Wrapping errors therefore inherit the code of the errors they wrap (even through wrappers of other types)
+// unless it's overridden by applying [WithCode] to the wrapping error.
+func CodeOf(err error) (Code, bool) {
+	if c, ok := errors.AsType[*coded](err); ok {
+		return c.code, true
+	}
+	return "", false
+}
+
+var (
+	codeLevelsMu sync.RWMutex
+	codeLevels   = map[Code]slog.Level{
+		CodeNotFound:        slog.LevelWarn,
+		CodeInvalidArgument: slog.LevelWarn,
+		CodePermission:      slog.LevelWarn,
+		CodeInternal:        slog.LevelError,
+	}
+)
+
+// SetCodeLevel sets the level at which [LogAuto] logs errors with the code. It's safe for concurrent use.
+func SetCodeLevel(code Code, level slog.Level) {
+	codeLevelsMu.Lock()
+	defer codeLevelsMu.Unlock()
+	codeLevels[code] = level
+}
+
+// LogAuto logs a structured error at the level given by its code (see [SetCodeLevel]).
+// Errors without a code or with a code without a level are logged at the error level.
+// By default, [CodeNotFound], [CodeInvalidArgument] and [CodePermission] are logged at the warn level
+// and [CodeInternal] at the error level.
+func LogAuto(ctx context.Context, logger *slog.Logger, err error) {
+	level := slog.LevelError
+	if code, ok := CodeOf(err); ok {
+		codeLevelsMu.RLock()
+		if l, ok := codeLevels[code]; ok {
+			level = l
+		}
+		codeLevelsMu.RUnlock()
+	}
+	Log(ctx, logger, level, err)
+}
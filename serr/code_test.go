@@ -0,0 +1,106 @@
+package serr
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCode(t *testing.T) {
+	req := require.New(t)
+
+	ErrSome := errors.New("some error")
+
+	err := WithCode(New("no such user", String("user", "abcd")), CodeNotFound)
+	req.Equal("no such user user=abcd", err.Error())
+	code, ok := CodeOf(err)
+	req.True(ok)
+	req.Equal(CodeNotFound, code)
+	req.Equal(http.StatusNotFound, ToHTTP(err))
+	req.Equal(codes.NotFound, status.Code(ToGRPC(err)))
+
+	_, ok = CodeOf(ErrSome)
+	req.False(ok)
+	req.Nil(WithCode(nil, CodeInternal))
+
+	err = WithCode(sql.ErrNoRows, CodeInvalidArgument)
+	req.True(errors.Is(err, sql.ErrNoRows))
+	req.Equal(http.StatusBadRequest, ToHTTP(err))
+	req.Equal(codes.InvalidArgument, status.Code(ToGRPC(err)))
+
+	err = WithCode(ErrSome, CodePermission)
+	req.Equal(http.StatusForbidden, ToHTTP(err))
+	req.Equal(codes.PermissionDenied, status.Code(ToGRPC(err)))
+
+	err = WithCode(sql.ErrNoRows, Code("custom"))
+	req.Equal(http.StatusNotFound, ToHTTP(err))
+
+	var buf bytes.Buffer
+	LogError(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)), WithCode(New("msg", String("a", "1")), CodeInternal))
+	req.Contains(buf.String(), `"msg":"msg","a":"1","code":"internal"`)
+}
+
+func TestCodeInheritance(t *testing.T) {
+	req := require.New(t)
+
+	inner := WithCode(New("no such user"), CodeNotFound)
+
+	err := Wrap("lookup failed", inner, String("user", "abcd"))
+	code, ok := CodeOf(err)
+	req.True(ok)
+	req.Equal(CodeNotFound, code)
+	req.Equal(http.StatusNotFound, ToHTTP(err))
+
+	err = Wrap("request failed", fmt.Errorf("handler: %w", err))
+	code, ok = CodeOf(err)
+	req.True(ok)
+	req.Equal(CodeNotFound, code)
+
+	err = WithCode(err, CodePermission)
+	code, ok = CodeOf(err)
+	req.True(ok)
+	req.Equal(CodePermission, code)
+	req.Equal(codes.PermissionDenied, status.Code(ToGRPC(err)))
+}
+
+func TestLogAuto(t *testing.T) {
+	req := require.New(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	ctx := context.Background()
+
+	LogAuto(ctx, logger, WithCode(New("no such user"), CodeNotFound))
+	req.Contains(buf.String(), `"level":"WARN","msg":"no such user"`)
+
+	buf.Reset()
+	LogAuto(ctx, logger, WithCode(New("db down"), CodeInternal))
+	req.Contains(buf.String(), `"level":"ERROR","msg":"db down"`)
+
+	buf.Reset()
+	LogAuto(ctx, logger, New("plain"))
+	req.Contains(buf.String(), `"level":"ERROR","msg":"plain"`)
+
+	buf.Reset()
+	LogAuto(ctx, logger, WithCode(New("custom"), Code("custom")))
+	req.Contains(buf.String(), `"level":"ERROR","msg":"custom"`)
+
+	SetCodeLevel(Code("custom"), slog.LevelInfo)
+	defer func() {
+		codeLevelsMu.Lock()
+		delete(codeLevels, Code("custom"))
+		codeLevelsMu.Unlock()
+	}()
+	buf.Reset()
+	LogAuto(ctx, logger, Wrap("wrapped", WithCode(New("custom"), Code("custom"))))
+	req.Contains(buf.String(), `"level":"INFO","msg":"wrapped: custom"`)
+}
@@ -0,0 +1,52 @@
+package serr
+
+import (
+	"sync"
+)
+
+// Collector accumulates errors, e.g. when processing many items. It's safe for concurrent use.
+type Collector struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewCollector creates a new error collector.
+func NewCollector() *Collector {
+	return new(Collector)
+}
+
+// Add adds an error to the collector. Nil errors are ignored.
+func (c *Collector) Add(err error) {
+	if err == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs = append(c.errs, err)
+}
+
+// AddFmt adds a new structured error with a formatted message to the collector.
+func (c *Collector) AddFmt(format string, args ...any) {
+	c.Add(Newf(format, args...))
+}
+
+// Len returns the number of collected errors.
+func (c *Collector) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.errs)
+}
+
+// Err returns nil if no errors have been collected, the error if there's exactly one
+// and an error wrapping all of them (see [WrapMulti]) otherwise.
+func (c *Collector) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch len(c.errs) {
+	case 0:
+		return nil
+	case 1:
+		return c.errs[0]
+	}
+	return WrapMulti("", append([]error(nil), c.errs...))
+}
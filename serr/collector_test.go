@@ -0,0 +1,28 @@
+package serr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	req := require.New(t)
+
+	ErrSome := errors.New("some error")
+
+	c := NewCollector()
+	c.Add(nil)
+	req.Equal(0, c.Len())
+	req.NoError(c.Err())
+
+	c.Add(ErrSome)
+	req.Equal(ErrSome, c.Err())
+
+	c.AddFmt("item %d failed", 2)
+	req.Equal(2, c.Len())
+	err := c.Err()
+	req.ErrorIs(err, ErrSome)
+	req.Equal("some error/item 2 failed", err.Error())
+}
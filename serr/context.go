@@ -0,0 +1,70 @@
+package serr
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	extractorsMu sync.Mutex
+	extractors   atomic.Pointer[[]func(context.Context) []Attr]
+)
+
+// RegisterContextExtractor registers a function extracting attributes (such as a request ID) from contexts.
+// The attributes extracted from the context passed to [Log] (and the other logging functions) are added
+// to the logged record unless the error already carries attributes with the same keys. [WrapCtx] adds
+// them to the error instead. It's safe for concurrent use.
+func RegisterContextExtractor(f func(context.Context) []Attr) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+	var fs []func(context.Context) []Attr
+	if p := extractors.Load(); p != nil {
+		fs = append(fs, *p...)
+	}
+	fs = append(fs, f)
+	extractors.Store(&fs)
+}
+
+func contextAttrs(ctx context.Context) []Attr {
+	p := extractors.Load()
+	if p == nil || ctx == nil {
+		return nil
+	}
+	var attrs []Attr
+	for _, f := range *p {
+		attrs = append(attrs, f(ctx)...)
+	}
+	return attrs
+}
+
+// logContextAttrs returns the attributes extracted from the context whose keys aren't carried by the error.
+func logContextAttrs(ctx context.Context, err error) []any {
+	attrs := contextAttrs(ctx)
+	if len(attrs) == 0 {
+		return nil
+	}
+	keys := make(map[string]struct{})
+	for _, attr := range AllAttributes(err) {
+		keys[attr.key] = struct{}{}
+	}
+	var r []Attributed
+	for _, attr := range attrs {
+		if _, ok := keys[attr.key]; !ok {
+			r = append(r, attr)
+		}
+	}
+	return attrsToSlog(r)
+}
+
+// WrapCtx is like [Wrap] but the error also carries the attributes extracted from the context
+// by the registered extractors (see [RegisterContextExtractor]). Unlike the enrichment at log time,
+// the attributes are captured when the error is created so they're kept even if the error is logged
+// with a different context (e.g. after being passed to another goroutine).
+func WrapCtx(ctx context.Context, msg string, err error, attrs ...Attributed) error {
+	attrs = append([]Attributed(nil), attrs...)
+	for _, attr := range contextAttrs(ctx) {
+		attrs = append(attrs, attr)
+	}
+	return Wrap(msg, err, attrs...)
+}
@@ -0,0 +1,47 @@
+package serr
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type requestIDKey struct{}
+
+func TestContextAttributes(t *testing.T) {
+	req := require.New(t)
+
+	defer extractors.Store(nil)
+	RegisterContextExtractor(func(ctx context.Context) []Attr {
+		if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+			return []Attr{String("request_id", id)}
+		}
+		return nil
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	ctx1 := context.WithValue(context.Background(), requestIDKey{}, "r1")
+	ctx2 := context.WithValue(context.Background(), requestIDKey{}, "r2")
+
+	err := WrapCtx(ctx1, "query failed", sql.ErrNoRows, String("table", "users"))
+	req.Equal("query failed: sql: no rows in result set table=users request_id=r1", err.Error())
+	LogError(ctx2, logger, err)
+	req.Contains(buf.String(), `"table":"users","request_id":"r1"}`)
+	req.NotContains(buf.String(), "r2")
+
+	buf.Reset()
+	LogError(ctx2, logger, New("some error", String("a", "1")))
+	req.Contains(buf.String(), `"a":"1","request_id":"r2"}`)
+
+	buf.Reset()
+	LogError(context.Background(), logger, New("some error"))
+	req.NotContains(buf.String(), "request_id")
+
+	err = WrapCtx(context.Background(), "query failed", sql.ErrNoRows)
+	req.Equal("query failed: sql: no rows in result set", err.Error())
+}
@@ -0,0 +1,63 @@
+package serr
+
+import (
+	"context"
+	"log/slog"
+)
+
+type handler struct {
+	inner slog.Handler
+}
+
+// NewHandler returns a slog handler which expands structured errors passed as attribute values.
+// An attribute whose value is an error carrying attributes (a structured error or an error implementing
+// [Attributed] anywhere in its chain) is replaced with a group of the same name containing the error's message
+// under the key "msg" and its attributes as they would be logged by [Log]. Other attributes are left unchanged.
+// The record is then passed on to the inner handler.
+func NewHandler(inner slog.Handler) slog.Handler {
+	return &handler{inner: inner}
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	rec := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(attr slog.Attr) bool {
+		rec.AddAttrs(expandAttr(attr))
+		return true
+	})
+	return h.inner.Handle(ctx, rec)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		expanded[i] = expandAttr(attr)
+	}
+	return &handler{inner: h.inner.WithAttrs(expanded)}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{inner: h.inner.WithGroup(name)}
+}
+
+func expandAttr(attr slog.Attr) slog.Attr {
+	val := attr.Value.Resolve()
+	switch val.Kind() {
+	case slog.KindGroup:
+		group := val.Group()
+		expanded := make([]slog.Attr, len(group))
+		for i, attr := range group {
+			expanded[i] = expandAttr(attr)
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(expanded...)}
+	case slog.KindAny:
+		if err, ok := val.Any().(error); ok && len(AllAttributes(err)) > 0 {
+			msg, attrs := logRecord(err)
+			return slog.Group(attr.Key, append([]any{slog.String("msg", msg)}, attrs...)...)
+		}
+	}
+	return attr
+}
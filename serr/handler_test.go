@@ -0,0 +1,29 @@
+package serr
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	req := require.New(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil)))
+
+	err := Wrap("lookup failed", New("no such user", String("user", "abcd")), Int("attempt", 2))
+	logger.Error("request failed", "err", err, "plain", errors.New("malheur"))
+	req.Contains(buf.String(), `"msg":"request failed","err":{"msg":"lookup failed: no such user user=abcd","attempt":2},"plain":"malheur"`)
+
+	buf.Reset()
+	logger.With("err", New("with attrs", Int("n", 1))).WithGroup("g").Error("grouped", "err", New("inner", Int("m", 2)))
+	req.Contains(buf.String(), `"err":{"msg":"with attrs","n":1},"g":{"err":{"msg":"inner","m":2}}`)
+
+	buf.Reset()
+	logger.Error("third party", slog.Group("details", "err", thirdPartyError{}))
+	req.Contains(buf.String(), `"details":{"err":{"msg":"third party","vendor":"acme"}}`)
+}
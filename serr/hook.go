@@ -0,0 +1,42 @@
+package serr
+
+import (
+	"sync/atomic"
+)
+
+// HookPoint specifies when an error hook is called.
+type HookPoint int
+
+// hook points (they can be combined)
+const (
+	// HookOnCreate calls the hook when a structured error is created.
+	HookOnCreate HookPoint = 1 << iota
+	// HookOnLog calls the hook when an error is logged.
+	HookOnLog
+)
+
+type errorHook struct {
+	f      func(error)
+	points HookPoint
+}
+
+var hook atomic.Pointer[errorHook]
+
+// SetErrorHook sets a function which is called with errors at the provided points, e.g. to collect metrics.
+// A nil function removes the hook. Panics in the hook are recovered and ignored.
+func SetErrorHook(f func(error), points HookPoint) {
+	if f == nil {
+		hook.Store(nil)
+		return
+	}
+	hook.Store(&errorHook{f: f, points: points})
+}
+
+func callHook(point HookPoint, err error) {
+	h := hook.Load()
+	if h == nil || h.points&point == 0 {
+		return
+	}
+	defer func() { _ = recover() }()
+	h.f(err)
+}
@@ -0,0 +1,40 @@
+package serr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorHook(t *testing.T) {
+	req := require.New(t)
+	defer SetErrorHook(nil, 0)
+
+	var created, logged []string
+	SetErrorHook(func(err error) { created = append(created, err.Error()) }, HookOnCreate)
+	err := Wrap("msg", New("inner"))
+	req.Equal([]string{"inner", "msg: inner"}, created)
+
+	created = nil
+	_ = WithCode(errors.New("plain"), CodeInternal)
+	req.Equal([]string{"plain"}, created)
+
+	SetErrorHook(func(err error) { logged = append(logged, err.Error()) }, HookOnLog)
+	LogError(context.Background(), slog.New(slog.NewJSONHandler(io.Discard, nil)), err)
+	New("ignored")
+	req.Equal([]string{"msg: inner"}, logged)
+
+	SetErrorHook(func(err error) { panic("hook") }, HookOnCreate|HookOnLog)
+	req.NotPanics(func() {
+		LogError(context.Background(), slog.New(slog.NewJSONHandler(io.Discard, nil)), WrapMulti("multi", []error{errors.New("x")}))
+	})
+
+	SetErrorHook(nil, HookOnCreate)
+	created = nil
+	New("no hook")
+	req.Nil(created)
+}
@@ -0,0 +1,129 @@
+package serr
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusClientClosedRequest is the non-standard status code used when the client closed the request.
+const StatusClientClosedRequest = 499
+
+// ToHTTP converts an error into an HTTP status code.
+func ToHTTP(err error) int {
+	if p, ok := errors.AsType[*problem](err); ok {
+		return p.status
+	}
+
+	if code, ok := CodeOf(err); ok {
+		if status, ok := codeToHTTP[code]; ok {
+			return status
+		}
+	}
+
+	switch {
+
+	case errors.Is(err, ErrNotPermitted):
+		return http.StatusUnauthorized
+
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+
+	case errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest
+
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+
+	case uuid.IsInvalidLengthError(err):
+		return http.StatusBadRequest
+
+	case err.Error() == "invalid UUID format":
+		return http.StatusBadRequest
+	}
+
+	if _, ok := errors.AsType[*json.SyntaxError](err); ok {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+type httpError struct {
+	Message    string         `json:"message"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// HandlerFunc returns an HTTP handler which calls the provided function.
+// If the function returns an error, the handler logs it at the error level using the default logger
+// and writes a JSON body with the message and attributes of the error and the status code given by [ToHTTP].
+// The message of an error not created by this package is the status text so that its details aren't leaked.
+func HandlerFunc(fn func(http.ResponseWriter, *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := fn(w, r)
+		if err == nil {
+			return
+		}
+		LogError(r.Context(), slog.Default(), err)
+		status := ToHTTP(err)
+		msg, attrs := messageAndAttrs(err, http.StatusText(status))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(httpError{Message: msg, Attributes: attrsToJSON(attrs)}); err != nil {
+			LogError(r.Context(), slog.Default(), Wrap("failed to write error response", err))
+		}
+	})
+}
+
+// messageAndAttrs returns the message and attributes of an error created by this package
+// or the provided fallback message if the error wasn't created by it.
+func messageAndAttrs(err error, fallback string) (string, []Attributed) {
+	switch err := err.(type) {
+	case *serror:
+		return err.msg, err.attrs
+	case *wrapped:
+		return err.message(), err.attrs
+	case *wrappedMulti:
+		return err.message(), err.attrs
+	case *problem:
+		return err.title, err.attrs
+	case *masked:
+		return err.msg, err.attrs
+	case *coded:
+		return messageAndAttrs(err.err, fallback)
+	}
+	return fallback, nil
+}
+
+func attrsToJSON(errAttrs []Attributed) map[string]any {
+	if len(errAttrs) == 0 {
+		return nil
+	}
+	attrs := make(map[string]any)
+	for _, attr := range errAttrs {
+		for _, attr := range attr.Attributes() {
+			attrs[attr.key] = attrToJSON(attr.value)
+		}
+	}
+	return attrs
+}
+
+func attrToJSON(val any) any {
+	switch val := val.(type) {
+	case string, int, uint, uuid.UUID, time.Time:
+		return val
+	case byteSize:
+		return int64(val)
+	case Loggable:
+		return val.LogString()
+	case error:
+		return val.Error()
+	}
+	return val
+}
@@ -0,0 +1,66 @@
+package serr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToHTTP(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(http.StatusNotFound, ToHTTP(Wrap("no user", sql.ErrNoRows)))
+	req.Equal(http.StatusUnauthorized, ToHTTP(ErrNotPermitted))
+	req.Equal(http.StatusInternalServerError, ToHTTP(errors.New("malheur")))
+	req.Equal(http.StatusGatewayTimeout, ToHTTP(context.DeadlineExceeded))
+	req.Equal(http.StatusGatewayTimeout, ToHTTP(Wrap("query failed", context.DeadlineExceeded)))
+	req.Equal(StatusClientClosedRequest, ToHTTP(context.Canceled))
+	req.Equal(StatusClientClosedRequest, ToHTTP(fmt.Errorf("query failed: %w", context.Canceled)))
+}
+
+func TestHandlerFunc(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		req := require.New(t)
+
+		h := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return Wrap("user not found", sql.ErrNoRows, String("user", "abcd"), Int("attempt", 2))
+		})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		req.Equal(http.StatusNotFound, rec.Code)
+		req.Equal("application/json", rec.Header().Get("Content-Type"))
+		req.JSONEq(`{"message":"user not found: sql: no rows in result set","attributes":{"user":"abcd","attempt":2}}`, rec.Body.String())
+	})
+
+	t.Run("foreign error", func(t *testing.T) {
+		req := require.New(t)
+
+		h := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return fmt.Errorf("dial tcp 10.0.0.1:5432: %w", errors.New("connection refused"))
+		})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		req.Equal(http.StatusInternalServerError, rec.Code)
+		req.JSONEq(`{"message":"Internal Server Error"}`, rec.Body.String())
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		req := require.New(t)
+
+		h := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte("ok"))
+			return nil
+		})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		req.Equal(http.StatusCreated, rec.Code)
+		req.Equal("ok", rec.Body.String())
+	})
+}
@@ -0,0 +1,30 @@
+package serr
+
+type masked struct {
+	msg   string
+	err   error
+	attrs []Attributed
+}
+
+func (se *masked) Error() string {
+	return (&serror{msg: se.msg, attrs: se.attrs}).Error()
+}
+
+// LogString returns the error's public message including its attributes.
+func (se *masked) LogString() string {
+	return se.Error()
+}
+
+func (se *masked) Unwrap() error {
+	return se.err
+}
+
+// Mask returns a new structured error which wraps the cause but whose message is only the public message
+// (including the attributes), i.e. the cause's message doesn't leak into client-facing error strings.
+// The cause is still found by [errors.Is] and [errors.As] and it's logged (see [Cause]) under the key "cause"
+// but its attributes aren't reported by [AllAttributes] and [HasAttr] nor rendered by [ProblemJSON] and [HandlerFunc].
+func Mask(publicMsg string, cause error, attrs ...Attributed) error {
+	err := &masked{msg: publicMsg, err: cause, attrs: attrs}
+	callHook(HookOnCreate, err)
+	return err
+}
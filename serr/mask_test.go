@@ -0,0 +1,53 @@
+package serr
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMask(t *testing.T) {
+	req := require.New(t)
+
+	cause := Wrap("query failed", sql.ErrNoRows, String("table", "users"))
+	err := Mask("user not found", cause, String("user", "abcd"))
+	req.Equal("user not found user=abcd", err.Error())
+	req.True(errors.Is(err, sql.ErrNoRows))
+	req.Equal(http.StatusNotFound, ToHTTP(err))
+	req.Equal([]Attr{String("user", "abcd")}, AllAttributes(err))
+	req.False(HasAttr(err, "table", "users"))
+
+	var buf bytes.Buffer
+	LogError(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)), err)
+	req.Contains(buf.String(), `"msg":"user not found","user":"abcd","cause":{"message":"query failed: sql: no rows in result set","table":"users"}`)
+
+	rec := httptest.NewRecorder()
+	HandlerFunc(func(http.ResponseWriter, *http.Request) error { return err }).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	req.NotContains(rec.Body.String(), "query failed")
+	req.Contains(rec.Body.String(), "user not found")
+}
+
+func TestMaskProblemJSON(t *testing.T) {
+	req := require.New(t)
+
+	err := Mask("user not found", Wrap("query failed", sql.ErrNoRows, String("table", "users"), String("dsn", "secret")))
+	b, jerr := ProblemJSON(err)
+	req.NoError(jerr)
+	req.NotContains(string(b), "secret")
+	req.NotContains(string(b), "users")
+	req.NotContains(string(b), "query failed")
+	req.Contains(string(b), `"detail":"user not found"`)
+
+	err = Wrap("request failed", Mask("user not found", New("query failed", String("dsn", "secret"))), String("path", "/users"))
+	b, jerr = ProblemJSON(err)
+	req.NoError(jerr)
+	req.NotContains(string(b), "secret")
+	req.Contains(string(b), `"path":"/users"`)
+}
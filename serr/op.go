@@ -0,0 +1,29 @@
+package serr
+
+// OpKey is the attribute key reserved for operation names. It shouldn't be used for other attributes.
+const OpKey = "op"
+
+// Op is an attribute naming the operation during which an error occurred.
+func Op(op string) Attr { return Attr{key: OpKey, value: op} }
+
+// WrapOp is like [Wrap] but it uses the operation name as the message and adds it as an [Op] attribute.
+func WrapOp(op string, err error, attrs ...Attributed) error {
+	return Wrap(op, err, append([]Attributed{Op(op)}, attrs...)...)
+}
+
+// OpChain returns the operation names carried by the errors in the tree of wrapped errors
+// from the outermost to the innermost one.
+func OpChain(err error) []string {
+	var ops []string
+	walk(err, func(err error) bool {
+		for _, attr := range errAttrs(err) {
+			for _, attr := range attr.Attributes() {
+				if op, ok := attr.value.(string); ok && attr.key == OpKey {
+					ops = append(ops, op)
+				}
+			}
+		}
+		return true
+	})
+	return ops
+}
@@ -0,0 +1,28 @@
+package serr
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOp(t *testing.T) {
+	req := require.New(t)
+
+	err := WrapOp("load user", sql.ErrNoRows, String("user", "abcd"))
+	req.Equal("load user: sql: no rows in result set op=load user user=abcd", err.Error())
+	req.True(errors.Is(err, sql.ErrNoRows))
+	req.True(HasAttr(err, "op", "load user"))
+
+	err = WrapOp("handle request", Wrap("", err))
+	err = Wrap("request failed", err, String("path", "/users"))
+	req.Equal([]string{"handle request", "load user"}, OpChain(err))
+
+	err = WrapMulti("batch failed", []error{WrapOp("a", sql.ErrNoRows), New("b", Op("b"))}, Op("batch"))
+	req.Equal([]string{"batch", "a", "b"}, OpChain(err))
+
+	req.Nil(OpChain(sql.ErrNoRows))
+	req.Nil(OpChain(nil))
+}
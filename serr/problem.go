@@ -0,0 +1,64 @@
+package serr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+type problem struct {
+	typ    string
+	title  string
+	status int
+	attrs  []Attributed
+}
+
+func (se *problem) Error() string {
+	return (&serror{msg: se.title, attrs: se.attrs}).Error()
+}
+
+// LogString returns the error's message including its attributes.
+func (se *problem) LogString() string {
+	return se.Error()
+}
+
+// Problem returns a new structured error representing an RFC 7807 problem detail.
+// The attributes become extension members of the problem detail object (see [ProblemJSON])
+// and the status is returned by [ToHTTP].
+func Problem(typ, title string, status int, attrs ...Attributed) error {
+	err := &problem{typ: typ, title: title, status: status, attrs: attrs}
+	callHook(HookOnCreate, err)
+	return err
+}
+
+// ProblemJSON renders an error as an RFC 7807 problem detail object.
+// The type, title and status are taken from the first error in the chain created with [Problem].
+// If there's none, the type is "about:blank" and the status is given by [ToHTTP].
+// The detail is the error's message unless it's equal to the title. The attributes of all errors
+// in the chain become extension members except for those whose keys clash with the standard members.
+func ProblemJSON(err error) ([]byte, error) {
+	obj := make(map[string]any)
+	for _, attr := range AllAttributes(err) {
+		switch attr.key {
+		case "type", "title", "status", "detail", "instance":
+		default:
+			obj[attr.key] = attrToJSON(attr.value)
+		}
+	}
+	msg, _ := messageAndAttrs(err, err.Error())
+	if p, ok := errors.AsType[*problem](err); ok {
+		obj["type"] = p.typ
+		obj["title"] = p.title
+		obj["status"] = p.status
+		if msg != p.title {
+			obj["detail"] = msg
+		}
+	} else {
+		status := ToHTTP(err)
+		obj["type"] = "about:blank"
+		obj["title"] = http.StatusText(status)
+		obj["status"] = status
+		obj["detail"] = msg
+	}
+	return json.Marshal(obj)
+}
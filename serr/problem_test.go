@@ -0,0 +1,50 @@
+package serr
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblem(t *testing.T) {
+	req := require.New(t)
+
+	err := Problem("https://example.com/probs/out-of-credit", "You do not have enough credit.", http.StatusForbidden,
+		Int("balance", 30), String("account", "/account/12345"))
+	req.Equal("You do not have enough credit. balance=30 account=/account/12345", err.Error())
+	req.Equal(http.StatusForbidden, ToHTTP(err))
+
+	b, jerr := ProblemJSON(err)
+	req.NoError(jerr)
+	req.JSONEq(`{
+		"type": "https://example.com/probs/out-of-credit",
+		"title": "You do not have enough credit.",
+		"status": 403,
+		"balance": 30,
+		"account": "/account/12345"
+	}`, string(b))
+
+	wrapped := Wrap("purchase failed", err, String("item", "abcd"), String("status", "ignored"))
+	req.Equal(http.StatusForbidden, ToHTTP(wrapped))
+	b, jerr = ProblemJSON(wrapped)
+	req.NoError(jerr)
+	req.JSONEq(`{
+		"type": "https://example.com/probs/out-of-credit",
+		"title": "You do not have enough credit.",
+		"status": 403,
+		"detail": "purchase failed: You do not have enough credit. balance=30 account=/account/12345",
+		"balance": 30,
+		"account": "/account/12345",
+		"item": "abcd"
+	}`, string(b))
+}
+
+func TestProblemJSONPlainError(t *testing.T) {
+	req := require.New(t)
+
+	b, err := ProblemJSON(errors.New("malheur"))
+	req.NoError(err)
+	req.JSONEq(`{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"malheur"}`, string(b))
+}
@@ -0,0 +1,77 @@
+package serr
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type sampleState struct {
+	mu         sync.Mutex
+	count      int
+	suppressed int
+	last       time.Time
+}
+
+var (
+	countSamples sync.Map
+	timeSamples  sync.Map
+	now          = time.Now
+)
+
+func sampleKey(err error) string {
+	msg, _ := logRecord(err)
+	code, _ := CodeOf(err)
+	return string(code) + "\x00" + msg
+}
+
+func sampleStateOf(states *sync.Map, err error) *sampleState {
+	st, _ := states.LoadOrStore(sampleKey(err), new(sampleState))
+	return st.(*sampleState)
+}
+
+// LogSampled logs a structured error at the provided level but only every nth occurrence of errors
+// with the same message and code (starting with the first one). The logged record has an additional attribute
+// "suppressed" with the number of occurrences which weren't logged since the last logged one.
+// If every <= 1, every occurrence is logged. It's safe for concurrent use. The counts are kept for the lifetime
+// of the process so errors whose messages contain unbounded data should be created with attributes instead.
+func LogSampled(ctx context.Context, logger *slog.Logger, level slog.Level, err error, every int) {
+	st := sampleStateOf(&countSamples, err)
+	st.mu.Lock()
+	st.count++
+	if every > 1 && (st.count-1)%every != 0 {
+		st.suppressed++
+		st.mu.Unlock()
+		return
+	}
+	suppressed := st.suppressed
+	st.suppressed = 0
+	st.mu.Unlock()
+	logSampled(ctx, logger, level, err, suppressed)
+}
+
+// LogSampledInterval is like [LogSampled] but it logs errors with the same message and code
+// at most once per interval (starting with the first occurrence).
+func LogSampledInterval(ctx context.Context, logger *slog.Logger, level slog.Level, err error, interval time.Duration) {
+	st := sampleStateOf(&timeSamples, err)
+	t := now()
+	st.mu.Lock()
+	if !st.last.IsZero() && t.Sub(st.last) < interval {
+		st.suppressed++
+		st.mu.Unlock()
+		return
+	}
+	st.last = t
+	suppressed := st.suppressed
+	st.suppressed = 0
+	st.mu.Unlock()
+	logSampled(ctx, logger, level, err, suppressed)
+}
+
+func logSampled(ctx context.Context, logger *slog.Logger, level slog.Level, err error, suppressed int) {
+	callHook(HookOnLog, err)
+	msg, attrs := logRecord(err)
+	attrs = append(attrs, logContextAttrs(ctx, err)...)
+	logger.Log(ctx, level, msg, append(attrs, slog.Int("suppressed", suppressed))...)
+}
@@ -0,0 +1,69 @@
+package serr
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogSampled(t *testing.T) {
+	req := require.New(t)
+
+	countSamples.Clear()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	ctx := context.Background()
+
+	for i := range 7 {
+		LogSampled(ctx, logger, slog.LevelError, New("sampled failure", Int("i", i)), 3)
+	}
+	LogSampled(ctx, logger, slog.LevelError, WithCode(New("sampled failure"), CodeInternal), 3)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	req.Len(lines, 4)
+	req.Contains(lines[0], `"i":0,"suppressed":0`)
+	req.Contains(lines[1], `"i":3,"suppressed":2`)
+	req.Contains(lines[2], `"i":6,"suppressed":2`)
+	req.Contains(lines[3], `"code":"internal","suppressed":0`)
+
+	buf.Reset()
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Go(func() {
+			LogSampled(ctx, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)), slog.LevelError, New("concurrent failure"), 10)
+		})
+	}
+	wg.Wait()
+	LogSampled(ctx, logger, slog.LevelError, New("concurrent failure"), 10)
+	req.Contains(buf.String(), `"suppressed":9`)
+}
+
+func TestLogSampledInterval(t *testing.T) {
+	req := require.New(t)
+
+	timeSamples.Clear()
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := t0
+	now = func() time.Time { return clock }
+	defer func() { now = time.Now }()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	ctx := context.Background()
+
+	for i := range 5 {
+		clock = t0.Add(time.Duration(i) * 400 * time.Millisecond)
+		LogSampledInterval(ctx, logger, slog.LevelWarn, New("timed failure", Int("i", i)), time.Second)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	req.Len(lines, 2)
+	req.Contains(lines[0], `"i":0,"suppressed":0`)
+	req.Contains(lines[1], `"i":3,"suppressed":2`)
+}
@@ -10,8 +10,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fealsamh/go-utils/nocopy"
@@ -43,6 +45,11 @@ func (se *serror) Error() string {
 	return sb.String()
 }
 
+// LogString returns the error's message including its attributes.
+func (se *serror) LogString() string {
+	return se.Error()
+}
+
 type wrapped struct {
 	msg   string
 	err   error
@@ -74,6 +81,11 @@ func (se *wrapped) Error() string {
 	return sb.String()
 }
 
+// LogString returns the error's message including its attributes.
+func (se *wrapped) LogString() string {
+	return se.Error()
+}
+
 func (se *wrapped) Unwrap() error {
 	return se.err
 }
@@ -113,6 +125,11 @@ func (se *wrappedMulti) Error() string {
 	return sb.String()
 }
 
+// LogString returns the error's message including its attributes.
+func (se *wrappedMulti) LogString() string {
+	return se.Error()
+}
+
 func (se *wrappedMulti) Unwrap() []error {
 	return se.errs
 }
@@ -133,6 +150,25 @@ func (a Attr) Attributes() []Attr {
 	return []Attr{a}
 }
 
+// Key returns the attribute's key.
+func (a Attr) Key() string { return a.key }
+
+// Value returns the attribute's value.
+func (a Attr) Value() any { return a.value }
+
+// String returns the attribute as it's rendered in error messages, i.e. as key=value.
+func (a Attr) String() string {
+	if logstr, ok := logString(a.value); ok {
+		return a.key + "=" + logstr
+	}
+	return fmt.Sprintf("%s=%v", a.key, a.value)
+}
+
+// GoString returns the attribute as a call of [Any].
+func (a Attr) GoString() string {
+	return fmt.Sprintf("serr.Any(%q, %#v)", a.key, a.value)
+}
+
 // String is a string-valued attribute.
 func String(key, value string) Attr { return Attr{key: key, value: value} }
 
@@ -153,7 +189,16 @@ func Any(key string, value any) Attr { return Attr{key: key, value: value} }
 
 // New returns a new structured error.
 func New(msg string, attrs ...Attributed) error {
-	return &serror{msg: msg, attrs: attrs}
+	err := &serror{msg: msg, attrs: attrs}
+	callHook(HookOnCreate, err)
+	return err
+}
+
+// Newf returns a new structured error with a formatted message.
+func Newf(format string, args ...any) error {
+	err := &serror{msg: fmt.Sprintf(format, args...)}
+	callHook(HookOnCreate, err)
+	return err
 }
 
 // Uint is an unsigned integer-valued attribute.
@@ -161,12 +206,60 @@ func Uint(key string, value uint) Attr { return Attr{key: key, value: value} }
 
 // Wrap returns a new structured error which wraps the provided error.
 func Wrap(msg string, err error, attrs ...Attributed) error {
-	return &wrapped{msg: msg, err: err, attrs: attrs}
+	werr := &wrapped{msg: msg, err: err, attrs: attrs}
+	callHook(HookOnCreate, werr)
+	return werr
+}
+
+// Wrapf returns a new structured error with a formatted message which wraps the provided error.
+func Wrapf(format string, err error, args ...any) error {
+	werr := &wrapped{msg: fmt.Sprintf(format, args...), err: err}
+	callHook(HookOnCreate, werr)
+	return werr
 }
 
 // WrapMulti returns a new structured error which wraps the provided errors.
 func WrapMulti(msg string, errs []error, attrs ...Attributed) error {
-	return &wrappedMulti{msg: msg, errs: errs, attrs: attrs}
+	err := &wrappedMulti{msg: msg, errs: errs, attrs: attrs}
+	callHook(HookOnCreate, err)
+	return err
+}
+
+// Join is the structured counterpart of [errors.Join]. It returns an error wrapping the non-nil errors
+// and carrying the attributes or nil if there are no non-nil errors.
+func Join(attrs []Attributed, errs ...error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	return WrapMulti("", nonNil, attrs...)
+}
+
+// WrapMultiFlat is like [WrapMulti] but errors created with WrapMulti (or WrapMultiFlat) are replaced
+// with the errors they wrap recursively so that the result wraps a flat list of errors.
+// The errors are listed in depth-first order, i.e. in the order in which they appear in the message.
+// The attributes of the replaced errors follow the provided attributes, their messages are dropped.
+func WrapMultiFlat(msg string, errs []error, attrs ...Attributed) error {
+	attrs = append([]Attributed(nil), attrs...)
+	var flat []error
+	var flatten func([]error)
+	flatten = func(errs []error) {
+		for _, err := range errs {
+			if multi, ok := err.(*wrappedMulti); ok {
+				attrs = append(attrs, multi.attrs...)
+				flatten(multi.errs)
+			} else {
+				flat = append(flat, err)
+			}
+		}
+	}
+	flatten(errs)
+	return WrapMulti(msg, flat, attrs...)
 }
 
 // LogDebug logs a structured error at the debug level.
@@ -191,18 +284,51 @@ func LogError(ctx context.Context, logger *slog.Logger, err error) {
 
 // Log logs a structured error at the provided level.
 func Log(ctx context.Context, logger *slog.Logger, level slog.Level, err error) {
+	callHook(HookOnLog, err)
+	msg, attrs := logRecord(err)
+	logger.Log(ctx, level, msg, append(attrs, logContextAttrs(ctx, err)...)...)
+}
+
+func logRecord(err error) (string, []any) {
 	switch err := err.(type) {
 	case *serror:
-		logger.Log(ctx, level, err.msg, attrsToSlog(err.attrs)...)
+		return err.msg, attrsToSlog(err.attrs)
 	case *wrapped:
-		logger.Log(ctx, level, err.message(), attrsToSlog(err.attrs)...)
+		return err.message(), attrsToSlog(err.attrs)
+	case *coded:
+		msg, attrs := logRecord(err.err)
+		return msg, append(attrs, slog.String("code", string(err.code)))
+	case *problem:
+		return err.title, attrsToSlog(err.attrs)
+	case *masked:
+		return err.msg, append(attrsToSlog(err.attrs), attrsToSlog([]Attributed{Cause("cause", err.err)})...)
 	case *wrappedMulti:
-		logger.Log(ctx, level, err.message(), attrsToSlog(err.attrs)...)
+		return err.message(), append(attrsToSlog(err.attrs), multiAttrsToSlog(err.errs)...)
+	case interface {
+		error
+		Unwrap() []error
+	}:
+		return err.Error(), append(attrsToSlog(errAttrs(err)), multiAttrsToSlog(err.Unwrap())...)
 	default:
-		logger.Log(ctx, level, err.Error())
+		var attributed Attributed
+		if errors.As(err, &attributed) {
+			return err.Error(), attrsToSlog([]Attributed{attributed})
+		}
+		return err.Error(), nil
 	}
 }
 
+// multiAttrsToSlog returns a group named err<i> with the attributes of the i-th error for each error carrying any.
+func multiAttrsToSlog(errs []error) []any {
+	var attrs []any
+	for i, err := range errs {
+		if errAttrs := AllAttributes(err); len(errAttrs) > 0 {
+			attrs = append(attrs, slog.Group("err"+strconv.Itoa(i), attrsToSlog(attrsToAttributed(errAttrs))...))
+		}
+	}
+	return attrs
+}
+
 func attrsToSlog(errAttrs []Attributed) []any {
 	attrs := make([]any, 0, len(errAttrs))
 	for _, attr := range errAttrs {
@@ -216,6 +342,12 @@ func attrsToSlog(errAttrs []Attributed) []any {
 				attrs = append(attrs, slog.String(attr.key, val.String()))
 			case time.Time:
 				attrs = append(attrs, slog.Time(attr.key, val))
+			case byteSize:
+				attrs = append(attrs, slog.Int64(attr.key, int64(val)))
+			case cause:
+				attrs = append(attrs, val.group(attr.key))
+			case Loggable:
+				attrs = append(attrs, slog.String(attr.key, val.LogString()))
 			case error:
 				attrs = append(attrs, slog.String(attr.key, val.Error()))
 			default:
@@ -230,7 +362,19 @@ func attrsToSlog(errAttrs []Attributed) []any {
 	return attrs
 }
 
+func attrsToAttributed(attrs []Attr) []Attributed {
+	r := make([]Attributed, len(attrs))
+	for i, attr := range attrs {
+		r[i] = attr
+	}
+	return r
+}
+
 func logString(val any) (string, bool) {
+	if logval, ok := val.(Loggable); ok {
+		return logval.LogString(), true
+	}
+
 	switch val := val.(type) {
 	case string:
 		return val, true
@@ -246,8 +390,8 @@ func logString(val any) (string, bool) {
 		return val.Error(), true
 	}
 
-	if logval, ok := val.(Loggable); ok {
-		return logval.LogString(), true
+	if f, ok := formatters.Load(reflect.TypeOf(val)); ok {
+		return f.(func(any) string)(val), true
 	}
 
 	b, err := json.MarshalIndent(val, "", " ")
@@ -257,6 +401,15 @@ func logString(val any) (string, bool) {
 	return nocopy.String(b), true
 }
 
+var formatters sync.Map
+
+// RegisterFormatter registers a function building the log representation of values of type T.
+// It's meant for types which can't implement [Loggable]. Registered formatters take precedence
+// over the JSON representation but not over [Loggable] or the built-in representations. It's safe for concurrent use.
+func RegisterFormatter[T any](f func(T) string) {
+	formatters.Store(reflect.TypeFor[T](), func(val any) string { return f(val.(T)) })
+}
+
 // Loggable indicates that the implementing type's instances build their own log representation.
 type Loggable interface {
 	LogString() string
@@ -271,11 +424,23 @@ var (
 func ToGRPC(err error) error {
 	msg := err.Error()
 
+	if code, ok := CodeOf(err); ok {
+		if c, ok := codeToGRPC[code]; ok {
+			return status.Error(c, msg)
+		}
+	}
+
 	switch {
 
 	case errors.Is(err, ErrNotPermitted):
 		return status.Error(codes.Unauthenticated, msg)
 
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, msg)
+
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, msg)
+
 	case errors.Is(err, sql.ErrNoRows):
 		return status.Error(codes.NotFound, msg)
 
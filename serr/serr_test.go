@@ -3,12 +3,16 @@ package serr
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"log/slog"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type attributed struct {
@@ -31,6 +35,21 @@ func TestAttributed(t *testing.T) {
 	req.Equal("dummy error attr=abcd id="+id.String()+" num=1234 wheels=3", err.Error())
 }
 
+func TestAttrString(t *testing.T) {
+	req := require.New(t)
+
+	attr := Int("count", 3)
+	req.Equal("count", attr.Key())
+	req.Equal(3, attr.Value())
+	req.Equal("count=3", attr.String())
+	req.Equal("count=3", fmt.Sprint(attr))
+	req.Equal(`serr.Any("count", 3)`, fmt.Sprintf("%#v", attr))
+	req.Equal(`user=abcd`, String("user", "abcd").String())
+	req.Equal(`serr.Any("user", "abcd")`, String("user", "abcd").GoString())
+	req.Equal("e=some error", Error("e", errors.New("some error")).String())
+	req.Equal([]Attr{attr}, attr.Attributes())
+}
+
 func TestErrorAttributes(t *testing.T) {
 	req := require.New(t)
 
@@ -55,6 +74,26 @@ func TestAnyAttributes(t *testing.T) {
 	req.Contains(buf.String(), `"level":"ERROR","msg":"msg","attr":"custom: data"`)
 }
 
+type thirdPartyError struct{}
+
+func (thirdPartyError) Error() string { return "third party" }
+
+func (thirdPartyError) Attributes() []Attr { return []Attr{String("vendor", "acme")} }
+
+func TestLogAttributedError(t *testing.T) {
+	req := require.New(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	LogError(context.Background(), logger, thirdPartyError{})
+	req.Contains(buf.String(), `"msg":"third party","vendor":"acme"`)
+
+	buf.Reset()
+	LogError(context.Background(), logger, fmt.Errorf("outer: %w", thirdPartyError{}))
+	req.Contains(buf.String(), `"msg":"outer: third party","vendor":"acme"`)
+}
+
 func TestWrappedErrors(t *testing.T) {
 	t.Run("message & wrapped error", func(t *testing.T) {
 		req := require.New(t)
@@ -70,6 +109,17 @@ func TestWrappedErrors(t *testing.T) {
 		req.Equal("msg: malheur/catastrophe a=1 b=2", err.Error())
 	})
 
+	t.Run("flattened wrapped errors", func(t *testing.T) {
+		req := require.New(t)
+
+		ErrA, ErrB, ErrC := errors.New("a"), errors.New("b"), errors.New("c")
+		nested := WrapMulti("nested", []error{ErrB, WrapMulti("", []error{ErrC}, String("y", "2"))}, String("x", "1"))
+		err := WrapMultiFlat("msg", []error{ErrA, nested}, String("top", "0"))
+		req.Equal("msg: a/b/c top=0 x=1 y=2", err.Error())
+		req.Equal([]error{ErrA, ErrB, ErrC}, err.(interface{ Unwrap() []error }).Unwrap())
+		req.True(errors.Is(err, ErrC))
+	})
+
 	t.Run("no message & error", func(t *testing.T) {
 		req := require.New(t)
 
@@ -87,6 +137,70 @@ func TestWrappedErrors(t *testing.T) {
 	})
 }
 
+func TestFormattedErrors(t *testing.T) {
+	req := require.New(t)
+
+	err := Newf("failed to process %s", "item")
+	req.Equal("failed to process item", err.Error())
+
+	ErrSome := errors.New("some error")
+	err = Wrapf("processing %s #%d", ErrSome, "item", 3)
+	req.Equal("processing item #3: some error", err.Error())
+	req.True(errors.Is(err, ErrSome))
+
+	var buf bytes.Buffer
+	LogError(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)), err)
+	req.Contains(buf.String(), `"msg":"processing item #3: some error"`)
+}
+
+func TestLogWrappedMulti(t *testing.T) {
+	req := require.New(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	err := WrapMulti("multi", []error{
+		New("first", String("a", "1")),
+		errors.New("plain"),
+		Wrap("third", New("inner", Int("b", 2)), String("c", "3")),
+	}, String("top", "x"))
+	LogError(context.Background(), logger, err)
+	req.Contains(buf.String(), `"top":"x","err0":{"a":"1"},"err2":{"c":"3","b":2}}`)
+}
+
+func TestLogJoined(t *testing.T) {
+	req := require.New(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	err := errors.Join(New("first", String("a", "1")), errors.New("plain"), Wrap("third", New("inner", Int("b", 2))))
+	req.Equal([]Attr{String("a", "1"), Int("b", 2)}, AllAttributes(err))
+	LogError(context.Background(), logger, err)
+	req.Contains(buf.String(), `"msg":"first a=1\nplain\nthird: inner b=2","err0":{"a":"1"},"err2":{"b":2}}`)
+
+	buf.Reset()
+	err = Join([]Attributed{String("top", "x")}, nil, New("first", String("a", "1")), sql.ErrNoRows)
+	req.True(errors.Is(err, sql.ErrNoRows))
+	req.Equal("first a=1/sql: no rows in result set top=x", err.Error())
+	LogError(context.Background(), logger, err)
+	req.Contains(buf.String(), `"top":"x","err0":{"a":"1"}}`)
+
+	req.Nil(Join([]Attributed{String("top", "x")}, nil, nil))
+	req.Nil(Join(nil))
+}
+
+func TestToGRPC(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(codes.NotFound, status.Code(ToGRPC(Wrap("no user", sql.ErrNoRows))))
+	req.Equal(codes.Internal, status.Code(ToGRPC(errors.New("malheur"))))
+	req.Equal(codes.DeadlineExceeded, status.Code(ToGRPC(context.DeadlineExceeded)))
+	req.Equal(codes.DeadlineExceeded, status.Code(ToGRPC(Wrap("query failed", context.DeadlineExceeded))))
+	req.Equal(codes.Canceled, status.Code(ToGRPC(context.Canceled)))
+	req.Equal(codes.Canceled, status.Code(ToGRPC(fmt.Errorf("query failed: %w", context.Canceled))))
+}
+
 type object1 struct {
 	Data string
 }
@@ -113,6 +227,39 @@ func TestLogString(t *testing.T) {
 }`)
 }
 
+func TestNestedErrorAttributes(t *testing.T) {
+	req := require.New(t)
+
+	nested := Wrap("nested", errors.New("malheur"), String("a", "1"))
+	err := New("msg", Any("cause", nested), Any("multi", WrapMulti("", []error{New("x", Int("n", 1))})))
+	req.Equal("msg cause=nested: malheur a=1 multi=x n=1", err.Error())
+
+	var buf bytes.Buffer
+	LogError(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)), err)
+	req.Contains(buf.String(), `"cause":"nested: malheur a=1","multi":"x n=1"`)
+
+	var l Loggable = New("x").(Loggable)
+	req.Equal("x", l.LogString())
+}
+
+type object3 struct {
+	Data string
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	req := require.New(t)
+
+	RegisterFormatter(func(obj object3) string { return "formatted: " + obj.Data })
+
+	logstr, ok := logString(object3{"OBJ3"})
+	req.True(ok)
+	req.Equal("formatted: OBJ3", logstr)
+
+	var buf bytes.Buffer
+	LogError(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)), New("msg", Any("obj", object3{"OBJ3"})))
+	req.Contains(buf.String(), `"obj":"formatted: OBJ3"`)
+}
+
 var gr any
 
 func BenchmarkAttrSlice(b *testing.B) {
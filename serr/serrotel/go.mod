@@ -0,0 +1,23 @@
+module github.com/phomola/gomisc/serr/serrotel
+
+go 1.26.5
+
+require (
+	github.com/phomola/gomisc v0.0.0-20261016004317-3d8b13b8a833
+	github.com/stretchr/testify v1.12.1
+	go.opentelemetry.io/otel/trace v1.46.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/fealsamh/go-utils v0.1.75 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/otel v1.46.0 // indirect
+	go.yaml.in/yaml/v3 v3.0.5 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260720171339-e059f2f05d78 // indirect
+	google.golang.org/grpc v1.82.1 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+)
+
+replace github.com/phomola/gomisc => ../..
@@ -0,0 +1,29 @@
+// Package serrotel provides structured error attributes for OpenTelemetry.
+// It's a separate module so that the serr package doesn't depend on OpenTelemetry.
+package serrotel
+
+import (
+	"context"
+
+	"github.com/phomola/gomisc/serr"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type attrs []serr.Attr
+
+func (a attrs) Attributes() []serr.Attr {
+	return a
+}
+
+// WithTrace returns the attributes trace_id and span_id of the span active in the context.
+// It returns no attributes if there's no active span.
+func WithTrace(ctx context.Context) serr.Attributed {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return attrs(nil)
+	}
+	return attrs{
+		serr.String("trace_id", sc.TraceID().String()),
+		serr.String("span_id", sc.SpanID().String()),
+	}
+}
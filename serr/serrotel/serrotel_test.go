@@ -0,0 +1,25 @@
+package serrotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/phomola/gomisc/serr"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithTrace(t *testing.T) {
+	req := require.New(t)
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+		SpanID:     trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	err := serr.New("some error", WithTrace(ctx))
+	req.Equal("some error trace_id=0102030405060708090a0b0c0d0e0f10 span_id=0102030405060708", err.Error())
+
+	err = serr.New("some error", WithTrace(context.Background()))
+	req.Equal("some error", err.Error())
+}
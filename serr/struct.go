@@ -0,0 +1,100 @@
+package serr
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errorType = reflect.TypeFor[error]()
+
+// WrapStruct returns a new structured error which wraps the provided error
+// and whose attributes are the exported fields of the provided struct (or pointer to a struct).
+// The key of an attribute is taken from the field's `serr` tag, then from its `json` tag
+// and it's the field's name if neither is present. Fields tagged with "-" are skipped.
+// Fields of nested structs are added with keys prefixed with the key of the struct and a dot
+// except for embedded structs without tags (other than [time.Time]) whose fields are added as if they were fields of the outer struct.
+// Values of types without a dedicated attribute constructor are added using [Any].
+func WrapStruct(msg string, err error, v any) error {
+	return Wrap(msg, err, structAttrs("", reflect.ValueOf(v))...)
+}
+
+func structAttrs(prefix string, v reflect.Value) []Attributed {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	var attrs []Attributed
+	for f := range v.Type().Fields() {
+		key, tagged := fieldKey(f)
+		if key == "-" {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		if f.Anonymous && !tagged {
+			if flattened(fv.Type()) {
+				attrs = append(attrs, structAttrs(prefix, fv)...)
+			} else if f.IsExported() {
+				attrs = append(attrs, fieldAttrs(prefix+f.Name, fv)...)
+			}
+			continue
+		}
+		if f.IsExported() {
+			attrs = append(attrs, fieldAttrs(prefix+key, fv)...)
+		}
+	}
+	return attrs
+}
+
+// flattened reports whether the fields of an embedded field of type t are added
+// as if they were fields of the outer struct.
+func flattened(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !hasConstructor(t)
+}
+
+func hasConstructor(t reflect.Type) bool {
+	return t == reflect.TypeFor[uuid.UUID]() || t == reflect.TypeFor[time.Time]()
+}
+
+func fieldKey(f reflect.StructField) (string, bool) {
+	for _, tag := range []string{"serr", "json"} {
+		if name, _, _ := strings.Cut(f.Tag.Get(tag), ","); name != "" {
+			return name, true
+		}
+	}
+	return f.Name, false
+}
+
+func fieldAttrs(key string, v reflect.Value) []Attributed {
+	switch v.Type() {
+	case reflect.TypeFor[uuid.UUID]():
+		return []Attributed{UUID(key, v.Interface().(uuid.UUID))}
+	case reflect.TypeFor[time.Time]():
+		return []Attributed{Time(key, v.Interface().(time.Time))}
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return []Attributed{String(key, v.String())}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []Attributed{Int(key, int(v.Int()))}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []Attributed{Uint(key, uint(v.Uint()))}
+	case reflect.Struct:
+		return structAttrs(key+".", v)
+	case reflect.Interface:
+		if v.Type().Implements(errorType) && !v.IsNil() {
+			return []Attributed{Error(key, v.Interface().(error))}
+		}
+	}
+	return []Attributed{Any(key, v.Interface())}
+}
@@ -0,0 +1,82 @@
+package serr
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type base struct {
+	RequestID string `json:"request_id"`
+}
+
+type address struct {
+	City string `json:"city"`
+}
+
+type request struct {
+	base
+	ID       uuid.UUID `serr:"id" json:"identifier"`
+	Name     string
+	Count    int       `json:"count,omitempty"`
+	Size     uint8     `serr:"size"`
+	Created  time.Time `json:"created"`
+	Address  address   `json:"address"`
+	Tags     []string  `json:"tags"`
+	Cause    error     `json:"cause"`
+	Secret   string    `serr:"-"`
+	internal string
+}
+
+func TestWrapStruct(t *testing.T) {
+	req := require.New(t)
+
+	id := uuid.New()
+	created := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	ErrSome := errors.New("some error")
+	r := request{
+		base:     base{RequestID: "r1"},
+		ID:       id,
+		Name:     "abcd",
+		Count:    3,
+		Size:     4,
+		Created:  created,
+		Address:  address{City: "Prague"},
+		Tags:     []string{"a"},
+		Cause:    errors.New("cause"),
+		Secret:   "password",
+		internal: "x",
+	}
+
+	err := WrapStruct("request failed", ErrSome, &r)
+	req.True(errors.Is(err, ErrSome))
+	req.Equal([]Attr{
+		String("request_id", "r1"),
+		UUID("id", id),
+		String("Name", "abcd"),
+		Int("count", 3),
+		Uint("size", 4),
+		Time("created", created),
+		String("address.city", "Prague"),
+		Any("tags", []string{"a"}),
+		Error("cause", r.Cause),
+	}, AllAttributes(err))
+	req.NotContains(err.Error(), "password")
+
+	req.Empty(AllAttributes(WrapStruct("msg", ErrSome, (*request)(nil))))
+
+	type event struct {
+		time.Time
+		uuid.UUID
+		base
+	}
+	e := event{Time: created, UUID: id, base: base{RequestID: "r2"}}
+	req.Equal([]Attr{
+		Time("Time", created),
+		UUID("UUID", id),
+		String("request_id", "r2"),
+	}, AllAttributes(WrapStruct("event failed", ErrSome, e)))
+}
@@ -0,0 +1,111 @@
+package slice
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/phomola/gomisc/serr"
+)
+
+// Entry is a key-value pair of a map.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// SortedKeys returns the keys of a map in ascending order.
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	r := make([]K, 0, len(m))
+	for k := range m {
+		r = append(r, k)
+	}
+	slices.Sort(r)
+	return r
+}
+
+// SortedEntries returns the entries of a map sorted by their keys in ascending order.
+func SortedEntries[K cmp.Ordered, V any](m map[K]V) []Entry[K, V] {
+	return SortedEntriesFunc(cmp.Less[K], m)
+}
+
+// SortedEntriesFunc returns the entries of a map sorted by their keys using the provided less function.
+func SortedEntriesFunc[K comparable, V any](less func(K, K) bool, m map[K]V) []Entry[K, V] {
+	r := make([]Entry[K, V], 0, len(m))
+	for k, v := range m {
+		r = append(r, Entry[K, V]{Key: k, Value: v})
+	}
+	slices.SortFunc(r, func(a, b Entry[K, V]) int {
+		switch {
+		case less(a.Key, b.Key):
+			return -1
+		case less(b.Key, a.Key):
+			return 1
+		}
+		return 0
+	})
+	return r
+}
+
+// MapToSlice applies a function to the entries of a map and returns the results in unspecified order.
+func MapToSlice[K comparable, V, R any](f func(K, V) R, m map[K]V) []R {
+	r := make([]R, 0, len(m))
+	for k, v := range m {
+		r = append(r, f(k, v))
+	}
+	return r
+}
+
+// SortedMapToSlice is like [MapToSlice] but the results are in the order of the keys given by the less function.
+func SortedMapToSlice[K comparable, V, R any](less func(K, K) bool, f func(K, V) R, m map[K]V) []R {
+	r := make([]R, 0, len(m))
+	for _, e := range SortedEntriesFunc(less, m) {
+		r = append(r, f(e.Key, e.Value))
+	}
+	return r
+}
+
+// FallibleMapValues applies a possibly erring function to the values of a map.
+// It stops at the first error which is returned wrapped along with the offending key.
+func FallibleMapValues[K comparable, V, W any](f func(V) (W, error), m map[K]V) (map[K]W, error) {
+	if m == nil {
+		return nil, nil
+	}
+	r := make(map[K]W, len(m))
+	for k, v := range m {
+		w, err := f(v)
+		if err != nil {
+			return nil, serr.Wrap("", err, serr.Any("key", k))
+		}
+		r[k] = w
+	}
+	return r, nil
+}
+
+// FallibleMapKeys applies a possibly erring function to the keys of a map.
+// It stops at the first error which is returned wrapped along with the offending key.
+// If several keys are mapped to the same key, it's unspecified which value is kept.
+func FallibleMapKeys[K, L comparable, V any](f func(K) (L, error), m map[K]V) (map[L]V, error) {
+	if m == nil {
+		return nil, nil
+	}
+	r := make(map[L]V, len(m))
+	for k, v := range m {
+		l, err := f(k)
+		if err != nil {
+			return nil, serr.Wrap("", err, serr.Any("key", k))
+		}
+		r[l] = v
+	}
+	return r, nil
+}
+
+// ZipToMap builds a map from parallel slices of keys and values. Only as many entries
+// as the length of the shorter slice are used. If a key occurs several times, the last value wins.
+func ZipToMap[K comparable, V any](keys []K, vals []V) map[K]V {
+	n := min(len(keys), len(vals))
+	r := make(map[K]V, n)
+	for i := range n {
+		r[keys[i]] = vals[i]
+	}
+	return r
+}
@@ -0,0 +1,60 @@
+package slice
+
+import (
+	"cmp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedEntries(t *testing.T) {
+	req := require.New(t)
+
+	m := map[string]int{"b": 2, "c": 3, "a": 1}
+	req.Equal([]string{"a", "b", "c"}, SortedKeys(m))
+	req.Equal([]Entry[string, int]{{"a", 1}, {"b", 2}, {"c", 3}}, SortedEntries(m))
+	req.Equal([]Entry[string, int]{{"c", 3}, {"b", 2}, {"a", 1}}, SortedEntriesFunc(func(a, b string) bool { return a > b }, m))
+	req.Empty(SortedEntries(map[string]int(nil)))
+}
+
+func TestMapToSlice(t *testing.T) {
+	req := require.New(t)
+
+	m := map[string]int{"b": 2, "a": 1, "c": 3}
+	f := func(k string, v int) string { return k + strconv.Itoa(v) }
+	req.ElementsMatch([]string{"a1", "b2", "c3"}, MapToSlice(f, m))
+	req.Equal([]string{"a1", "b2", "c3"}, SortedMapToSlice(cmp.Less[string], f, m))
+	req.Equal([]string{"c3", "b2", "a1"}, SortedMapToSlice(func(a, b string) bool { return a > b }, f, m))
+	req.Empty(MapToSlice(f, nil))
+}
+
+func TestFallibleMap(t *testing.T) {
+	req := require.New(t)
+
+	m, err := FallibleMapValues(strconv.Atoi, map[string]string{"a": "1", "b": "2"})
+	req.NoError(err)
+	req.Equal(map[string]int{"a": 1, "b": 2}, m)
+
+	_, err = FallibleMapValues(strconv.Atoi, map[string]string{"a": "1", "b": "x"})
+	req.ErrorContains(err, "key=b")
+
+	k, err := FallibleMapKeys(strconv.Atoi, map[string]bool{"1": true, "2": false})
+	req.NoError(err)
+	req.Equal(map[int]bool{1: true, 2: false}, k)
+
+	_, err = FallibleMapKeys(strconv.Atoi, map[string]bool{"x": true})
+	req.ErrorContains(err, "key=x")
+
+	m, err = FallibleMapValues(strconv.Atoi, map[string]string(nil))
+	req.NoError(err)
+	req.Nil(m)
+}
+
+func TestZipToMap(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(map[string]int{"a": 1, "b": 2}, ZipToMap([]string{"a", "b", "c"}, []int{1, 2}))
+	req.Equal(map[string]int{"a": 3, "b": 2}, ZipToMap([]string{"a", "b", "a"}, []int{1, 2, 3, 4}))
+	req.Equal(map[string]int{}, ZipToMap[string, int](nil, nil))
+}
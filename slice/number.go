@@ -0,0 +1,42 @@
+package slice
+
+import (
+	"github.com/phomola/gomisc/maybe"
+)
+
+// Number is a constraint satisfied by all integer and floating-point types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sum returns the sum of the elements (zero for an empty slice).
+func Sum[T Number](l []T) T {
+	var r T
+	for _, x := range l {
+		r += x
+	}
+	return r
+}
+
+// Product returns the product of the elements (one for an empty slice).
+func Product[T Number](l []T) T {
+	var r T = 1
+	for _, x := range l {
+		r *= x
+	}
+	return r
+}
+
+// Average returns the arithmetic mean of the elements or nothing for an empty slice.
+func Average[T Number](l []T) maybe.Maybe[float64] {
+	if len(l) == 0 {
+		return maybe.Nothing[float64]()
+	}
+	var r float64
+	for _, x := range l {
+		r += float64(x)
+	}
+	return maybe.Unit(r / float64(len(l)))
+}
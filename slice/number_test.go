@@ -0,0 +1,25 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/phomola/gomisc/maybe"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSum(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(10, Sum([]int{1, 2, 3, 4}))
+	req.Equal(0, Sum[int](nil))
+	req.Equal(24, Product([]int{1, 2, 3, 4}))
+	req.Equal(1.0, Product[float64](nil))
+}
+
+func TestAverage(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(maybe.Unit(2.5), Average([]int{1, 2, 3, 4}))
+	req.Equal(maybe.Unit(255.0), Average([]uint8{255, 255}))
+	req.Equal(maybe.Nothing[float64](), Average[int](nil))
+}
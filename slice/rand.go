@@ -0,0 +1,39 @@
+package slice
+
+import "math/rand/v2"
+
+// Shuffle returns a shuffled copy of the slice. If r is nil, the default source is used.
+func Shuffle[T any](r *rand.Rand, l []T) []T {
+	if l == nil {
+		return nil
+	}
+	return ShuffleInPlace(r, append([]T(nil), l...))
+}
+
+// ShuffleInPlace shuffles the slice and returns it. If r is nil, the default source is used.
+func ShuffleInPlace[T any](r *rand.Rand, l []T) []T {
+	swap := func(i, j int) { l[i], l[j] = l[j], l[i] }
+	if r == nil {
+		rand.Shuffle(len(l), swap)
+	} else {
+		r.Shuffle(len(l), swap)
+	}
+	return l
+}
+
+// Sample returns n elements of the slice chosen at random without replacement (using reservoir sampling).
+// The sample contains all the elements if n >= len(l) and it's empty if n <= 0. If r is nil, the default source is used.
+func Sample[T any](r *rand.Rand, n int, l []T) []T {
+	n = min(max(n, 0), len(l))
+	intN := rand.IntN
+	if r != nil {
+		intN = r.IntN
+	}
+	s := append(make([]T, 0, n), l[:n]...)
+	for i := n; i < len(l); i++ {
+		if j := intN(i + 1); j < n {
+			s[j] = l[i]
+		}
+	}
+	return s
+}
@@ -0,0 +1,42 @@
+package slice
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShuffle(t *testing.T) {
+	req := require.New(t)
+
+	l := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	s := Shuffle(rand.New(rand.NewPCG(1, 2)), l)
+	req.ElementsMatch(l, s)
+	req.Equal([]int{1, 2, 3, 4, 5, 6, 7, 8}, l)
+	req.Equal(s, Shuffle(rand.New(rand.NewPCG(1, 2)), l))
+	req.ElementsMatch(l, Shuffle(nil, l))
+	req.Nil(Shuffle[int](nil, nil))
+
+	m := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	req.Equal(s, ShuffleInPlace(rand.New(rand.NewPCG(1, 2)), m))
+	req.Equal(s, m)
+}
+
+func TestSample(t *testing.T) {
+	req := require.New(t)
+
+	l := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	s := Sample(rand.New(rand.NewPCG(1, 2)), 3, l)
+	req.Len(s, 3)
+	for _, x := range s {
+		req.Contains(l, x)
+	}
+	req.Len(toSet(s), 3)
+	req.Equal(s, Sample(rand.New(rand.NewPCG(1, 2)), 3, l))
+	req.Len(Sample(nil, 3, l), 3)
+	req.ElementsMatch(l, Sample(nil, 10, l))
+	req.Empty(Sample(nil, 0, l))
+	req.Empty(Sample(nil, -1, l))
+	req.Empty(Sample[int](nil, 2, nil))
+}
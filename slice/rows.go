@@ -0,0 +1,34 @@
+package slice
+
+import (
+	"database/sql"
+
+	"github.com/phomola/gomisc/serr"
+)
+
+// FromRows builds a slice by calling the provided function on each row. The rows are closed on return.
+// A row which fails to be scanned doesn't stop the iteration, the errors of all such rows
+// are returned together with the error of the iteration itself (if any).
+func FromRows[T any](rows *sql.Rows, scan func(*sql.Rows) (T, error)) ([]T, error) {
+	defer rows.Close()
+
+	var (
+		r    []T
+		errs []error
+	)
+	for i := 0; rows.Next(); i++ {
+		x, err := scan(rows)
+		if err != nil {
+			errs = append(errs, serr.Wrap("failed to scan row", err, serr.Int("row", i)))
+			continue
+		}
+		r = append(r, x)
+	}
+	if err := rows.Err(); err != nil {
+		errs = append(errs, serr.Wrap("failed to iterate rows", err))
+	}
+	if len(errs) > 0 {
+		return nil, serr.WrapMulti("failed to read rows", errs)
+	}
+	return r, nil
+}
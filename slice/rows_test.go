@@ -0,0 +1,48 @@
+package slice
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/phomola/gomisc/internal/fakesql"
+	"github.com/stretchr/testify/require"
+)
+
+func queryFake(t *testing.T, c *fakesql.Connector) *sql.Rows {
+	db := sql.OpenDB(c)
+	t.Cleanup(func() { db.Close() })
+	rows, err := db.Query("select")
+	require.NoError(t, err)
+	return rows
+}
+
+func TestFromRows(t *testing.T) {
+	scanName := func(rows *sql.Rows) (string, error) {
+		var name string
+		err := rows.Scan(&name)
+		return name, err
+	}
+
+	t.Run("success", func(t *testing.T) {
+		req := require.New(t)
+
+		rows := queryFake(t, &fakesql.Connector{Cols: []string{"name"}, Rows: [][]driver.Value{{"a"}, {"b"}}})
+		names, err := FromRows(rows, scanName)
+		req.NoError(err)
+		req.Equal([]string{"a", "b"}, names)
+		req.ErrorContains(rows.Scan(new(string)), "closed")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		req := require.New(t)
+
+		ErrBroken := errors.New("broken connection")
+		rows := queryFake(t, &fakesql.Connector{Cols: []string{"name"}, Rows: [][]driver.Value{{"a"}, {nil}, {"c"}}, Err: ErrBroken})
+		_, err := FromRows(rows, scanName)
+		req.ErrorIs(err, ErrBroken)
+		req.ErrorContains(err, "failed to scan row")
+		req.ErrorContains(err, "row=1")
+	})
+}
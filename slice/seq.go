@@ -0,0 +1,77 @@
+package slice
+
+import (
+	"iter"
+
+	"github.com/phomola/gomisc/maybe"
+)
+
+// FmapSeq is a lazy functorial map over a sequence.
+func FmapSeq[T, U any](f func(T) U, seq iter.Seq[T]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for x := range seq {
+			if !yield(f(x)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq lazily yields the elements of a sequence satisfying the predicate.
+func FilterSeq[T any](pred func(T) bool, seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for x := range seq {
+			if pred(x) && !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Collect collects the elements of a sequence into a slice.
+func Collect[T any](seq iter.Seq[T]) []T {
+	var r []T
+	for x := range seq {
+		r = append(r, x)
+	}
+	return r
+}
+
+// ToSeq returns a sequence yielding the elements of a slice.
+func ToSeq[T any](l []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, x := range l {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// FindSeq returns the first element of a sequence satisfying the predicate.
+// The sequence isn't pulled from after the first match.
+func FindSeq[T any](pred func(T) bool, seq iter.Seq[T]) maybe.Maybe[T] {
+	for x := range seq {
+		if pred(x) {
+			return maybe.Unit(x)
+		}
+	}
+	return maybe.Nothing[T]()
+}
+
+// ChunkSeq lazily yields consecutive chunks of the provided size (the last one can be shorter).
+// The chunks share the underlying array with the input slice (no copies are made)
+// and it's therefore safe to retain them. It panics if size is less than 1.
+func ChunkSeq[T any](size int, l []T) iter.Seq[[]T] {
+	if size < 1 {
+		panic("slice.ChunkSeq: size must be positive")
+	}
+	return func(yield func([]T) bool) {
+		for i := 0; i < len(l); i += size {
+			j := min(i+size, len(l))
+			if !yield(l[i:j:j]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package slice
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/phomola/gomisc/maybe"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeq(t *testing.T) {
+	req := require.New(t)
+
+	seq := FmapSeq(strconv.Itoa, FilterSeq(func(x int) bool { return x%2 == 1 }, ToSeq([]int{1, 2, 3, 4, 5})))
+	req.Equal([]string{"1", "3", "5"}, Collect(seq))
+	req.Nil(Collect(ToSeq[int](nil)))
+
+	var r []string
+	for x := range seq {
+		r = append(r, x)
+		if len(r) == 2 {
+			break
+		}
+	}
+	req.Equal([]string{"1", "3"}, r)
+}
+
+func TestFindSeq(t *testing.T) {
+	req := require.New(t)
+
+	pulled, stopped := 0, false
+	seq := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for i := 1; i <= 10; i++ {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	req.Equal(maybe.Unit(3), FindSeq(func(x int) bool { return x%3 == 0 }, seq))
+	req.Equal(3, pulled)
+	req.True(stopped)
+
+	req.Equal(maybe.Nothing[int](), FindSeq(func(x int) bool { return x > 10 }, ToSeq([]int{1, 2, 3})))
+}
+
+func TestChunkSeq(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([][]int{{1, 2}, {3, 4}, {5}}, Collect(ChunkSeq(2, []int{1, 2, 3, 4, 5})))
+	req.Nil(Collect(ChunkSeq[int](2, nil)))
+	req.Panics(func() { ChunkSeq(0, []int{1}) })
+
+	for chunk := range ChunkSeq(2, []int{1, 2, 3}) {
+		req.Equal(2, cap(chunk))
+		break
+	}
+}
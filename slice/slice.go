@@ -1,7 +1,15 @@
 package slice
 
 import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
 	"github.com/fealsamh/go-utils/function"
+	"github.com/phomola/gomisc/maybe"
+	"github.com/phomola/gomisc/serr"
+	"github.com/phomola/gomisc/tuple"
 )
 
 // Fmap is a functorial map.
@@ -16,6 +24,21 @@ func Fmap[T, U any](f func(T) U, l []T) []U {
 	return r
 }
 
+// Map is equivalent to [Fmap].
+func Map[T, U any](f func(T) U, l []T) []U {
+	return Fmap(f, l)
+}
+
+// FmapInPlace is like [Fmap] for functions which preserve the type but it replaces
+// the elements of the provided slice and returns it instead of allocating a new one.
+// The result aliases the input so the caller must own the slice.
+func FmapInPlace[T any](f func(T) T, l []T) []T {
+	for i, x := range l {
+		l[i] = f(x)
+	}
+	return l
+}
+
 // SetFmap is a functorial map.
 func SetFmap[T comparable, U any](f func(T) U, s map[T]struct{}) []U {
 	r := make([]U, 0, len(s))
@@ -37,6 +60,32 @@ func Bind[T, U any](f func(T) []U, l []T) []U {
 	return r
 }
 
+// BindMaybe is like [Bind] for functions returning at most one result. It collects the valid results in order.
+func BindMaybe[T, U any](f func(T) maybe.Maybe[U], l []T) []U {
+	if l == nil {
+		return nil
+	}
+	var r []U
+	for _, x := range l {
+		if y := f(x); y.Valid {
+			r = append(r, y.Val)
+		}
+	}
+	return r
+}
+
+// Apply is the applicative application. It applies all the functions to the first element,
+// then to the second one, etc. The result is empty if there are no functions or no elements.
+func Apply[T, U any](fs []func(T) U, l []T) []U {
+	r := make([]U, 0, len(fs)*len(l))
+	for _, x := range l {
+		for _, f := range fs {
+			r = append(r, f(x))
+		}
+	}
+	return r
+}
+
 // Join is the monadic join operation.
 func Join[T any](x [][]T) []T {
 	if x == nil {
@@ -61,6 +110,27 @@ func FallibleFmap[T, U any](f func(T) (U, error), l []T) ([]U, error) {
 	return r, nil
 }
 
+// FmapContext is a functorial map for a possibly erring function which takes a context.
+// The elements are processed sequentially and the context is checked before each of them.
+// If it's done, the context's error is returned wrapped along with the index of the element.
+func FmapContext[T, U any](ctx context.Context, f func(context.Context, T) (U, error), l []T) ([]U, error) {
+	if l == nil {
+		return nil, nil
+	}
+	r := make([]U, len(l))
+	for i, x := range l {
+		if err := ctx.Err(); err != nil {
+			return nil, serr.Wrap("context done", err, serr.Int("index", i))
+		}
+		y, err := f(ctx, x)
+		if err != nil {
+			return nil, err
+		}
+		r[i] = y
+	}
+	return r, nil
+}
+
 // FallibleSetFmap is a functorial map for a possibly erring function.
 func FallibleSetFmap[T comparable, U any](f func(T) (U, error), s map[T]struct{}) ([]U, error) {
 	r := make([]U, 0, len(s))
@@ -73,3 +143,674 @@ func FallibleSetFmap[T comparable, U any](f func(T) (U, error), s map[T]struct{}
 	}
 	return r, nil
 }
+
+// Count returns the number of elements satisfying the predicate.
+func Count[T any](pred func(T) bool, l []T) int {
+	n := 0
+	for _, x := range l {
+		if pred(x) {
+			n++
+		}
+	}
+	return n
+}
+
+// CountBy returns the number of occurrences of each key produced by the projection.
+func CountBy[T any, K comparable](key func(T) K, l []T) map[K]int {
+	r := make(map[K]int)
+	for _, x := range l {
+		r[key(x)]++
+	}
+	return r
+}
+
+// Chunk splits a slice into consecutive chunks of the provided size (the last one can be shorter).
+// The chunks share the underlying array with the input slice. It panics if size is less than 1.
+func Chunk[T any](size int, l []T) [][]T {
+	if size < 1 {
+		panic("slice.Chunk: size must be positive")
+	}
+	if l == nil {
+		return nil
+	}
+	r := make([][]T, 0, (len(l)+size-1)/size)
+	for i := 0; i < len(l); i += size {
+		j := min(i+size, len(l))
+		r = append(r, l[i:j:j])
+	}
+	return r
+}
+
+// ProcessBatches calls the provided function on consecutive chunks of the provided size.
+// It stops at the first error which is returned wrapped along with the index of the batch.
+func ProcessBatches[T any](size int, l []T, fn func([]T) error) error {
+	if size < 1 {
+		return serr.New("invalid batch size", serr.Int("size", size))
+	}
+	for i, batch := range Chunk(size, l) {
+		if err := fn(batch); err != nil {
+			return serr.Wrap("batch failed", err, serr.Int("batch", i))
+		}
+	}
+	return nil
+}
+
+// Compact returns the elements which aren't equal to the zero value.
+func Compact[T comparable](l []T) []T {
+	var zero T
+	return CompactFunc(func(x T) bool { return x == zero }, l)
+}
+
+// CompactFunc returns the elements for which the provided function returns false.
+func CompactFunc[T any](isZero func(T) bool, l []T) []T {
+	if l == nil {
+		return nil
+	}
+	r := make([]T, 0, len(l))
+	for _, x := range l {
+		if !isZero(x) {
+			r = append(r, x)
+		}
+	}
+	return r
+}
+
+// Equal reports whether two slices contain the same elements in the same order.
+func Equal[T comparable](a, b []T) bool {
+	return EqualFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// EqualFunc reports whether two slices contain the same elements in the same order
+// using the provided equality function.
+func EqualFunc[T any](a, b []T, eq func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualUnordered reports whether two slices contain the same elements with the same multiplicities
+// regardless of their order.
+func EqualUnordered[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[T]int, len(a))
+	for _, x := range a {
+		counts[x]++
+	}
+	for _, x := range b {
+		if counts[x] == 0 {
+			return false
+		}
+		counts[x]--
+	}
+	return true
+}
+
+// Find returns the first element of the slice satisfying the predicate.
+func Find[T any](pred func(T) bool, l []T) maybe.Maybe[T] {
+	if i := FindIndex(pred, l); i >= 0 {
+		return maybe.Unit(l[i])
+	}
+	return maybe.Nothing[T]()
+}
+
+// FindIndex returns the index of the first element of the slice satisfying the predicate or -1 if there's none.
+func FindIndex[T any](pred func(T) bool, l []T) int {
+	for i, x := range l {
+		if pred(x) {
+			return i
+		}
+	}
+	return -1
+}
+
+// FindLast returns the last element of the slice satisfying the predicate.
+func FindLast[T any](pred func(T) bool, l []T) maybe.Maybe[T] {
+	for i := len(l) - 1; i >= 0; i-- {
+		if pred(l[i]) {
+			return maybe.Unit(l[i])
+		}
+	}
+	return maybe.Nothing[T]()
+}
+
+// AllEqual reports whether all elements of the slice are equal. It's true for slices with fewer than two elements.
+func AllEqual[T comparable](l []T) bool {
+	for _, x := range l[min(1, len(l)):] {
+		if x != l[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSorted reports whether the slice is sorted in ascending order.
+func IsSorted[T cmp.Ordered](l []T) bool {
+	return IsSortedBy(cmp.Less[T], l)
+}
+
+// IsSortedBy reports whether the slice is sorted by the less function.
+func IsSortedBy[T any](less func(T, T) bool, l []T) bool {
+	for i := 1; i < len(l); i++ {
+		if less(l[i], l[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Indexed is an element of a slice paired with its index.
+type Indexed[T any] struct {
+	Index int
+	Value T
+}
+
+// Enumerate pairs each element with its index.
+func Enumerate[T any](l []T) []Indexed[T] {
+	if l == nil {
+		return nil
+	}
+	r := make([]Indexed[T], len(l))
+	for i, x := range l {
+		r[i] = Indexed[T]{Index: i, Value: x}
+	}
+	return r
+}
+
+// SplitAt splits a slice into the first n elements and the rest.
+// The index is clamped to the bounds of the slice. Both parts are copies.
+func SplitAt[T any](n int, l []T) ([]T, []T) {
+	n = min(max(n, 0), len(l))
+	return append([]T(nil), l[:n]...), append([]T(nil), l[n:]...)
+}
+
+// RemoveAt returns a copy of the slice without the element at index i.
+// It panics if i is out of the range [0, len(l)).
+func RemoveAt[T any](i int, l []T) []T {
+	checkIndex("RemoveAt", i, len(l))
+	r := make([]T, 0, len(l)-1)
+	return append(append(r, l[:i]...), l[i+1:]...)
+}
+
+// InsertAt returns a copy of the slice with x inserted at index i.
+// It panics if i is out of the range [0, len(l)].
+func InsertAt[T any](i int, x T, l []T) []T {
+	checkIndex("InsertAt", i, len(l)+1)
+	r := make([]T, 0, len(l)+1)
+	return append(append(append(r, l[:i]...), x), l[i:]...)
+}
+
+// Replace returns a copy of the slice with the element at index i replaced by x.
+// It panics if i is out of the range [0, len(l)).
+func Replace[T any](i int, x T, l []T) []T {
+	checkIndex("Replace", i, len(l))
+	r := append([]T(nil), l...)
+	r[i] = x
+	return r
+}
+
+func checkIndex(fn string, i, n int) {
+	if i < 0 || i >= n {
+		panic(fmt.Sprintf("slice.%s: index %d out of range [0, %d)", fn, i, n))
+	}
+}
+
+// SplitFunc splits a slice into runs of elements separated by elements satisfying the predicate.
+// The separators aren't included in the result and neither are empty runs (like [strings.FieldsFunc]).
+// The runs are copies.
+func SplitFunc[T any](pred func(T) bool, l []T) [][]T {
+	var (
+		r   [][]T
+		run []T
+	)
+	for _, x := range l {
+		if pred(x) {
+			if len(run) > 0 {
+				r = append(r, run)
+				run = nil
+			}
+		} else {
+			run = append(run, x)
+		}
+	}
+	if len(run) > 0 {
+		r = append(r, run)
+	}
+	return r
+}
+
+// Reduce folds a slice using its first element as the initial value.
+// It returns nothing for an empty slice.
+func Reduce[T any](f func(T, T) T, l []T) maybe.Maybe[T] {
+	if len(l) == 0 {
+		return maybe.Nothing[T]()
+	}
+	r := l[0]
+	for _, x := range l[1:] {
+		r = f(r, x)
+	}
+	return maybe.Unit(r)
+}
+
+// Intersperse inserts a separator between each pair of consecutive elements.
+// The result is always a copy.
+func Intersperse[T any](sep T, l []T) []T {
+	if l == nil {
+		return nil
+	}
+	r := make([]T, 0, max(2*len(l)-1, 0))
+	for i, x := range l {
+		if i > 0 {
+			r = append(r, sep)
+		}
+		r = append(r, x)
+	}
+	return r
+}
+
+// Zip pairs up the elements of two slices. The result is as long as the shorter slice.
+func Zip[A, B any](as []A, bs []B) []tuple.Pair[A, B] {
+	if as == nil || bs == nil {
+		return nil
+	}
+	n := min(len(as), len(bs))
+	r := make([]tuple.Pair[A, B], n)
+	for i := range n {
+		r[i] = tuple.MakePair(as[i], bs[i])
+	}
+	return r
+}
+
+// Merge merges two slices sorted by the less function into a sorted slice.
+// It's stable: if elements of both slices are equal, those of the first slice come first.
+// The result is unspecified if the slices aren't sorted by the less function.
+func Merge[T any](less func(T, T) bool, a, b []T) []T {
+	if a == nil && b == nil {
+		return nil
+	}
+	r := make([]T, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if less(b[0], a[0]) {
+			r, b = append(r, b[0]), b[1:]
+		} else {
+			r, a = append(r, a[0]), a[1:]
+		}
+	}
+	return append(append(r, a...), b...)
+}
+
+// Unfold builds a slice from a seed by repeatedly calling the step function,
+// which returns the next element and the next seed, until it returns false.
+// The step function must eventually return false, otherwise Unfold doesn't terminate.
+func Unfold[S, T any](seed S, step func(S) (T, S, bool)) []T {
+	var r []T
+	for {
+		x, next, ok := step(seed)
+		if !ok {
+			return r
+		}
+		r = append(r, x)
+		seed = next
+	}
+}
+
+// Cycle repeats the elements of the slice in order until there are n of them (the last cycle can be incomplete).
+// The result is empty if the slice is empty or if n <= 0.
+func Cycle[T any](n int, l []T) []T {
+	if len(l) == 0 || n <= 0 {
+		return []T{}
+	}
+	r := make([]T, n)
+	for i := 0; i < n; i += len(l) {
+		copy(r[i:], l)
+	}
+	return r
+}
+
+// Rotate returns a copy of a slice rotated left by n positions (right for negative n).
+func Rotate[T any](n int, l []T) []T {
+	if l == nil {
+		return nil
+	}
+	r := make([]T, len(l))
+	if len(l) == 0 {
+		return r
+	}
+	n %= len(l)
+	if n < 0 {
+		n += len(l)
+	}
+	copy(r, l[n:])
+	copy(r[len(l)-n:], l[:n])
+	return r
+}
+
+// Shift returns the first element of a slice (or nothing if it's empty) and the rest of it.
+func Shift[T any](l []T) (maybe.Maybe[T], []T) {
+	if len(l) == 0 {
+		return maybe.Nothing[T](), l
+	}
+	return maybe.Unit(l[0]), l[1:]
+}
+
+// ForEach calls the provided function on each element.
+// It stops at the first error which is returned wrapped along with the index of the element.
+func ForEach[T any](f func(T) error, l []T) error {
+	return ForEachIndex(func(_ int, x T) error { return f(x) }, l)
+}
+
+// ForEachIndex calls the provided function on each element and its index.
+// It stops at the first error which is returned wrapped along with the index of the element.
+func ForEachIndex[T any](f func(int, T) error, l []T) error {
+	for i, x := range l {
+		if err := f(i, x); err != nil {
+			return serr.Wrap("", err, serr.Int("index", i))
+		}
+	}
+	return nil
+}
+
+func toSet[T comparable](l []T) map[T]struct{} {
+	s := make(map[T]struct{}, len(l))
+	for _, x := range l {
+		s[x] = struct{}{}
+	}
+	return s
+}
+
+// Intersect returns the distinct elements of a which are also in b in the order of a.
+func Intersect[T comparable](a, b []T) []T {
+	inB := toSet(b)
+	seen := make(map[T]struct{})
+	var r []T
+	for _, x := range a {
+		if _, ok := inB[x]; !ok {
+			continue
+		}
+		if _, ok := seen[x]; ok {
+			continue
+		}
+		seen[x] = struct{}{}
+		r = append(r, x)
+	}
+	return r
+}
+
+// Difference returns the elements of a which aren't in b in the order of a (including duplicates).
+func Difference[T comparable](a, b []T) []T {
+	inB := toSet(b)
+	var r []T
+	for _, x := range a {
+		if _, ok := inB[x]; !ok {
+			r = append(r, x)
+		}
+	}
+	return r
+}
+
+// Diff returns the distinct elements of newL which aren't in oldL (added) and the distinct elements of oldL
+// which aren't in newL (removed), both in the order of their first occurrence.
+func Diff[T comparable](oldL, newL []T) (added, removed []T) {
+	return DiffBy(function.Identity, oldL, newL)
+}
+
+// DiffBy is like [Diff] but elements are compared by the keys produced by the projection.
+// Of several elements with the same key, the first one is returned.
+func DiffBy[T any, K comparable](key func(T) K, oldL, newL []T) (added, removed []T) {
+	return diffBy(key, newL, oldL), diffBy(key, oldL, newL)
+}
+
+func diffBy[T any, K comparable](key func(T) K, a, b []T) []T {
+	seen := make(map[K]struct{}, len(b))
+	for _, x := range b {
+		seen[key(x)] = struct{}{}
+	}
+	var r []T
+	for _, x := range a {
+		k := key(x)
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			r = append(r, x)
+		}
+	}
+	return r
+}
+
+// Frequency is a distinct element of a slice along with the number of its occurrences.
+type Frequency[T any] struct {
+	Value T
+	Count int
+}
+
+// TopN returns the n most frequent distinct elements sorted by their counts in descending order.
+// Ties are broken by the order of first appearance. The number n is clamped to the number of distinct elements.
+func TopN[T comparable](n int, l []T) []Frequency[T] {
+	counts := CountBy(function.Identity, l)
+	r := make([]Frequency[T], 0, len(counts))
+	for _, x := range l {
+		if c, ok := counts[x]; ok {
+			r = append(r, Frequency[T]{Value: x, Count: c})
+			delete(counts, x)
+		}
+	}
+	slices.SortStableFunc(r, func(a, b Frequency[T]) int { return cmp.Compare(b.Count, a.Count) })
+	return r[:min(max(n, 0), len(r))]
+}
+
+// Product2 returns all pairs of elements of two slices (the first component varies slowest).
+// The result has len(as)*len(bs) elements.
+func Product2[A, B any](as []A, bs []B) []tuple.Pair[A, B] {
+	r := make([]tuple.Pair[A, B], 0, len(as)*len(bs))
+	for _, a := range as {
+		for _, b := range bs {
+			r = append(r, tuple.MakePair(a, b))
+		}
+	}
+	return r
+}
+
+// CartesianN returns the cartesian product of the provided slices (the first slice varies slowest).
+// The result has as many elements as the product of the lengths of the slices so it grows exponentially
+// with their number. It's empty if there are no slices or if any of them is empty.
+func CartesianN[T any](ls [][]T) [][]T {
+	if len(ls) == 0 {
+		return [][]T{}
+	}
+	n := 1
+	for _, l := range ls {
+		n *= len(l)
+	}
+	r := make([][]T, 0, n)
+	if n == 0 {
+		return r
+	}
+	idx := make([]int, len(ls))
+	for {
+		t := make([]T, len(ls))
+		for i, j := range idx {
+			t[i] = ls[i][j]
+		}
+		r = append(r, t)
+		i := len(ls) - 1
+		for ; i >= 0; i-- {
+			idx[i]++
+			if idx[i] < len(ls[i]) {
+				break
+			}
+			idx[i] = 0
+		}
+		if i < 0 {
+			return r
+		}
+	}
+}
+
+// Combinations returns all k-element combinations of the elements of the slice.
+// The elements of each combination follow their order in the slice. The result has
+// binomial(len(l), k) elements so it grows very quickly with the slice's length.
+// It's empty if k <= 0 or k > len(l).
+func Combinations[T any](l []T, k int) [][]T {
+	if k <= 0 || k > len(l) {
+		return [][]T{}
+	}
+	var r [][]T
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = i
+	}
+	for {
+		c := make([]T, k)
+		for i, j := range idx {
+			c[i] = l[j]
+		}
+		r = append(r, c)
+		i := k - 1
+		for ; i >= 0 && idx[i] == len(l)-k+i; i-- {
+		}
+		if i < 0 {
+			return r
+		}
+		idx[i]++
+		for j := i + 1; j < k; j++ {
+			idx[j] = idx[j-1] + 1
+		}
+	}
+}
+
+// Pairs returns all 2-element combinations of the elements of the slice, i.e. each pair
+// of elements at indices i < j. The result has len(l)*(len(l)-1)/2 elements.
+func Pairs[T any](l []T) []tuple.Pair[T, T] {
+	n := len(l)
+	r := make([]tuple.Pair[T, T], 0, max(n*(n-1)/2, 0))
+	for i, x := range l {
+		for _, y := range l[i+1:] {
+			r = append(r, tuple.MakePair(x, y))
+		}
+	}
+	return r
+}
+
+// KeyBy indexes the elements by the keys produced by the projection.
+// If several elements have the same key, the last one wins.
+func KeyBy[T any, K comparable](key func(T) K, l []T) map[K]T {
+	r := make(map[K]T, len(l))
+	for _, x := range l {
+		r[key(x)] = x
+	}
+	return r
+}
+
+// KeyByUnique indexes the elements by the keys produced by the projection.
+// It returns an error if several elements have the same key.
+func KeyByUnique[T any, K comparable](key func(T) K, l []T) (map[K]T, error) {
+	r := make(map[K]T, len(l))
+	for i, x := range l {
+		k := key(x)
+		if _, ok := r[k]; ok {
+			return nil, serr.New("duplicate key", serr.Any("key", k), serr.Int("index", i))
+		}
+		r[k] = x
+	}
+	return r, nil
+}
+
+// MultiIndex indexes the elements by the keys produced by all the projections, i.e. an element can be found
+// under several keys. The elements under each key are in the order of the slice. If several projections
+// produce the same key for an element, the element is indexed under it only once.
+func MultiIndex[T any, K comparable](keyFns []func(T) K, l []T) map[K][]T {
+	r := make(map[K][]T)
+	keys := make([]K, 0, len(keyFns))
+	for _, x := range l {
+		keys = keys[:0]
+		for _, key := range keyFns {
+			if k := key(x); !slices.Contains(keys, k) {
+				keys = append(keys, k)
+				r[k] = append(r[k], x)
+			}
+		}
+	}
+	return r
+}
+
+// Span splits a slice into the longest prefix of elements satisfying the predicate and the rest.
+// Both parts are copies.
+func Span[T any](pred func(T) bool, l []T) ([]T, []T) {
+	i := 0
+	for i < len(l) && pred(l[i]) {
+		i++
+	}
+	return SplitAt(i, l)
+}
+
+// Break splits a slice into the longest prefix of elements not satisfying the predicate and the rest.
+// Both parts are copies.
+func Break[T any](pred func(T) bool, l []T) ([]T, []T) {
+	return Span(func(x T) bool { return !pred(x) }, l)
+}
+
+// ChunkBy splits a slice into runs of consecutive elements with the same key.
+// The runs are copies.
+func ChunkBy[T any, K comparable](key func(T) K, l []T) [][]T {
+	if l == nil {
+		return nil
+	}
+	r := [][]T{}
+	var last K
+	for i, x := range l {
+		k := key(x)
+		if i == 0 || k != last {
+			r = append(r, nil)
+			last = k
+		}
+		r[len(r)-1] = append(r[len(r)-1], x)
+	}
+	return r
+}
+
+// Run is a run of consecutive elements with the same key.
+type Run[K comparable, T any] struct {
+	Key   K
+	Items []T
+}
+
+// RunsBy is like [ChunkBy] but it returns the runs along with their keys.
+func RunsBy[T any, K comparable](key func(T) K, l []T) []Run[K, T] {
+	if l == nil {
+		return nil
+	}
+	r := []Run[K, T]{}
+	for _, x := range l {
+		k := key(x)
+		if len(r) == 0 || k != r[len(r)-1].Key {
+			r = append(r, Run[K, T]{Key: k})
+		}
+		r[len(r)-1].Items = append(r[len(r)-1].Items, x)
+	}
+	return r
+}
+
+// Transpose swaps the rows and columns of a matrix.
+// Ragged input is padded with zero values, i.e. the result has as many rows as the longest input row.
+func Transpose[T any](rows [][]T) [][]T {
+	n := 0
+	for _, row := range rows {
+		n = max(n, len(row))
+	}
+	r := make([][]T, n)
+	for i := range r {
+		r[i] = make([]T, len(rows))
+		for j, row := range rows {
+			if i < len(row) {
+				r[i][j] = row[i]
+			}
+		}
+	}
+	return r
+}
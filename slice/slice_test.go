@@ -1,10 +1,15 @@
 package slice
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/phomola/gomisc/maybe"
+	"github.com/phomola/gomisc/tuple"
 	"github.com/stretchr/testify/require"
 )
 
@@ -12,6 +17,8 @@ func TestFmap(t *testing.T) {
 	req := require.New(t)
 
 	req.Equal([]string{"1", "2", "3"}, Fmap(func(x int) string { return strconv.Itoa(x) }, []int{1, 2, 3}))
+	req.Equal([]string{"1", "2", "3"}, Map(strconv.Itoa, []int{1, 2, 3}))
+	req.Nil(Map(strconv.Itoa, nil))
 
 	x, err := FallibleFmap(func(x int) (string, error) { return strconv.Itoa(x), nil }, []int{1, 2, 3})
 	req.NoError(err)
@@ -21,8 +28,520 @@ func TestFmap(t *testing.T) {
 	req.Error(err)
 }
 
+func TestFmapInPlace(t *testing.T) {
+	req := require.New(t)
+
+	l := []int{1, 2, 3}
+	r := FmapInPlace(func(x int) int { return x * 2 }, l)
+	req.Equal([]int{2, 4, 6}, r)
+	req.Equal([]int{2, 4, 6}, l)
+	req.Same(&l[0], &r[0])
+	req.Nil(FmapInPlace(func(x int) int { return x }, nil))
+}
+
+func BenchmarkFmap(b *testing.B) {
+	l := make([]int, 1<<20)
+	b.ReportAllocs()
+	for b.Loop() {
+		l = Fmap(func(x int) int { return x + 1 }, l)
+	}
+}
+
+func BenchmarkFmapInPlace(b *testing.B) {
+	l := make([]int, 1<<20)
+	b.ReportAllocs()
+	for b.Loop() {
+		l = FmapInPlace(func(x int) int { return x + 1 }, l)
+	}
+}
+
+func TestFmapContext(t *testing.T) {
+	req := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	x, err := FmapContext(ctx, func(ctx context.Context, x int) (string, error) { return strconv.Itoa(x), nil }, []int{1, 2, 3})
+	req.NoError(err)
+	req.Equal([]string{"1", "2", "3"}, x)
+
+	var processed []int
+	_, err = FmapContext(ctx, func(ctx context.Context, x int) (string, error) {
+		processed = append(processed, x)
+		if x == 2 {
+			cancel()
+		}
+		return strconv.Itoa(x), nil
+	}, []int{1, 2, 3})
+	req.ErrorIs(err, context.Canceled)
+	req.Equal("context done: context canceled index=2", err.Error())
+	req.Equal([]int{1, 2}, processed)
+}
+
+func TestBindMaybe(t *testing.T) {
+	req := require.New(t)
+
+	parse := func(s string) maybe.Maybe[int] { return maybe.FromResult(strconv.Atoi(s)) }
+	req.Equal([]int{1, 3}, BindMaybe(parse, []string{"1", "x", "3"}))
+	req.Empty(BindMaybe(parse, []string{"x"}))
+	req.Nil(BindMaybe(parse, nil))
+}
+
+func TestApply(t *testing.T) {
+	req := require.New(t)
+
+	fs := []func(int) string{strconv.Itoa, func(x int) string { return strings.Repeat("*", x) }}
+	req.Equal([]string{"1", "*", "2", "**"}, Apply(fs, []int{1, 2}))
+	req.Empty(Apply(fs, nil))
+	req.Empty(Apply[int, string](nil, []int{1, 2}))
+}
+
 func TestJoin(t *testing.T) {
 	req := require.New(t)
 
 	req.Equal([]int{1, 2, 3, 4, 5}, Join([][]int{{1, 2}, {}, {3, 4, 5}}))
 }
+
+func TestCount(t *testing.T) {
+	req := require.New(t)
+
+	even := func(x int) bool { return x%2 == 0 }
+	req.Equal(2, Count(even, []int{1, 2, 3, 4, 5}))
+	req.Equal(0, Count(even, nil))
+
+	req.Equal(map[bool]int{true: 2, false: 3}, CountBy(even, []int{1, 2, 3, 4, 5}))
+	req.Equal(map[bool]int{}, CountBy(even, nil))
+}
+
+func TestChunk(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([][]int{{1, 2}, {3, 4}, {5}}, Chunk(2, []int{1, 2, 3, 4, 5}))
+	req.Nil(Chunk[int](2, nil))
+	req.Panics(func() { Chunk(0, []int{1}) })
+}
+
+func TestProcessBatches(t *testing.T) {
+	req := require.New(t)
+
+	var batches [][]int
+	err := ProcessBatches(2, []int{1, 2, 3}, func(b []int) error {
+		batches = append(batches, b)
+		return nil
+	})
+	req.NoError(err)
+	req.Equal([][]int{{1, 2}, {3}}, batches)
+
+	err = ProcessBatches(2, []int{1, 2, 3, 4, 5}, func(b []int) error {
+		if b[0] == 3 {
+			return errors.ErrUnsupported
+		}
+		return nil
+	})
+	req.ErrorIs(err, errors.ErrUnsupported)
+	req.Equal("batch failed: unsupported operation batch=1", err.Error())
+
+	req.Error(ProcessBatches(0, []int{1}, func([]int) error { return nil }))
+}
+
+func TestCompact(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([]string{"a", "b"}, Compact([]string{"", "a", "", "b"}))
+	req.Equal([]int{}, Compact([]int{0, 0}))
+	req.Nil(Compact[int](nil))
+	req.Equal([][]int{{1}}, CompactFunc(func(x []int) bool { return len(x) == 0 }, [][]int{nil, {1}, {}}))
+}
+
+func TestEqual(t *testing.T) {
+	req := require.New(t)
+
+	req.True(Equal([]int{1, 2, 3}, []int{1, 2, 3}))
+	req.False(Equal([]int{1, 2, 3}, []int{3, 2, 1}))
+	req.True(Equal[int](nil, []int{}))
+	req.True(EqualFunc([]string{"a"}, []string{"A"}, strings.EqualFold))
+
+	req.True(EqualUnordered([]int{1, 2, 2, 3}, []int{2, 3, 2, 1}))
+	req.False(EqualUnordered([]int{1, 1, 2}, []int{1, 2, 2}))
+	req.False(EqualUnordered([]int{1}, []int{1, 1}))
+}
+
+func TestFind(t *testing.T) {
+	req := require.New(t)
+
+	even := func(x int) bool { return x%2 == 0 }
+	l := []int{1, 2, 3, 4, 5}
+	req.Equal(maybe.Unit(2), Find(even, l))
+	req.Equal(1, FindIndex(even, l))
+	req.Equal(maybe.Unit(4), FindLast(even, l))
+
+	l = []int{1, 3}
+	req.Equal(maybe.Nothing[int](), Find(even, l))
+	req.Equal(-1, FindIndex(even, l))
+	req.Equal(maybe.Nothing[int](), FindLast(even, l))
+	req.Equal(-1, FindIndex(even, nil))
+}
+
+func TestAllEqual(t *testing.T) {
+	req := require.New(t)
+
+	req.True(AllEqual([]int{2, 2, 2}))
+	req.False(AllEqual([]int{2, 2, 3}))
+	req.True(AllEqual([]int{2}))
+	req.True(AllEqual([]int{}))
+	req.True(AllEqual[int](nil))
+}
+
+func TestIsSorted(t *testing.T) {
+	req := require.New(t)
+
+	req.True(IsSorted([]int{1, 2, 2, 3}))
+	req.False(IsSorted([]int{1, 3, 2}))
+	req.True(IsSorted([]string{"a"}))
+	req.True(IsSorted[int](nil))
+	req.True(IsSortedBy(func(a, b int) bool { return a > b }, []int{3, 2, 2, 1}))
+	req.False(IsSortedBy(func(a, b int) bool { return a > b }, []int{1, 2}))
+}
+
+func TestEnumerate(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([]Indexed[string]{{0, "a"}, {1, "b"}}, Enumerate([]string{"a", "b"}))
+	req.Nil(Enumerate[int](nil))
+}
+
+func TestSplit(t *testing.T) {
+	req := require.New(t)
+
+	l := []int{1, 2, 3, 4}
+	head, tail := SplitAt(1, l)
+	req.Equal([]int{1}, head)
+	req.Equal([]int{2, 3, 4}, tail)
+	head[0] = 5
+	req.Equal(1, l[0])
+
+	head, tail = SplitAt(10, l)
+	req.Equal(l, head)
+	req.Nil(tail)
+
+	head, tail = SplitAt(-1, l)
+	req.Nil(head)
+	req.Equal(l, tail)
+
+	zero := func(x int) bool { return x == 0 }
+	req.Equal([][]int{{1, 2}, {3}, {4}}, SplitFunc(zero, []int{0, 1, 2, 0, 0, 3, 0, 4}))
+	req.Nil(SplitFunc(zero, []int{0, 0}))
+}
+
+func TestRemoveAt(t *testing.T) {
+	req := require.New(t)
+
+	l := []int{1, 2, 3}
+	req.Equal([]int{2, 3}, RemoveAt(0, l))
+	req.Equal([]int{1, 3}, RemoveAt(1, l))
+	req.Equal([]int{1, 2}, RemoveAt(2, l))
+	req.Equal([]int{1, 2, 3}, l)
+	req.PanicsWithValue("slice.RemoveAt: index 3 out of range [0, 3)", func() { RemoveAt(3, l) })
+	req.PanicsWithValue("slice.RemoveAt: index -1 out of range [0, 3)", func() { RemoveAt(-1, l) })
+}
+
+func TestInsertAt(t *testing.T) {
+	req := require.New(t)
+
+	l := []int{1, 2, 3}
+	req.Equal([]int{0, 1, 2, 3}, InsertAt(0, 0, l))
+	req.Equal([]int{1, 0, 2, 3}, InsertAt(1, 0, l))
+	req.Equal([]int{1, 2, 3, 0}, InsertAt(3, 0, l))
+	req.Equal([]int{1, 2, 3}, l)
+	req.Equal([]int{0}, InsertAt(0, 0, []int(nil)))
+	req.PanicsWithValue("slice.InsertAt: index 4 out of range [0, 4)", func() { InsertAt(4, 0, l) })
+}
+
+func TestReplace(t *testing.T) {
+	req := require.New(t)
+
+	l := []int{1, 2, 3}
+	req.Equal([]int{1, 0, 3}, Replace(1, 0, l))
+	req.Equal([]int{1, 2, 3}, l)
+	req.PanicsWithValue("slice.Replace: index 3 out of range [0, 3)", func() { Replace(3, 0, l) })
+}
+
+func TestMerge(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([]int{1, 2, 3, 4, 5, 6}, Merge(cmp.Less[int], []int{1, 4, 5}, []int{2, 3, 6}))
+	req.Equal([]int{1, 2}, Merge(cmp.Less[int], []int{1, 2}, nil))
+	req.Equal([]int{1, 2}, Merge(cmp.Less[int], nil, []int{1, 2}))
+	req.Nil(Merge[int](cmp.Less[int], nil, nil))
+
+	a := []tuple.Pair[int, string]{tuple.MakePair(1, "a"), tuple.MakePair(2, "a")}
+	b := []tuple.Pair[int, string]{tuple.MakePair(1, "b"), tuple.MakePair(2, "b")}
+	byFirst := func(x, y tuple.Pair[int, string]) bool { return x.First < y.First }
+	req.Equal([]tuple.Pair[int, string]{
+		tuple.MakePair(1, "a"),
+		tuple.MakePair(1, "b"),
+		tuple.MakePair(2, "a"),
+		tuple.MakePair(2, "b"),
+	}, Merge(byFirst, a, b))
+}
+
+func TestReduce(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(maybe.Unit(3), Reduce(func(x, y int) int { return max(x, y) }, []int{1, 3, 2}))
+	req.Equal(maybe.Unit("a-b-c"), Reduce(func(x, y string) string { return x + "-" + y }, []string{"a", "b", "c"}))
+	req.Equal(maybe.Nothing[int](), Reduce(func(x, y int) int { return x + y }, nil))
+}
+
+func TestIntersperse(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([]string{"a", ",", "b", ",", "c"}, Intersperse(",", []string{"a", "b", "c"}))
+	req.Equal([]string{"a"}, Intersperse(",", []string{"a"}))
+	req.Equal([]string{}, Intersperse(",", []string{}))
+	req.Nil(Intersperse(",", nil))
+}
+
+func TestZip(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([]tuple.Pair[int, string]{tuple.MakePair(1, "a"), tuple.MakePair(2, "b")}, Zip([]int{1, 2, 3}, []string{"a", "b"}))
+	req.Nil(Zip[int, string](nil, []string{"a"}))
+}
+
+func TestUnfold(t *testing.T) {
+	req := require.New(t)
+
+	powers := Unfold(1, func(x int) (int, int, bool) { return x, 2 * x, x <= 100 })
+	req.Equal([]int{1, 2, 4, 8, 16, 32, 64}, powers)
+	req.Nil(Unfold(0, func(x int) (int, int, bool) { return 0, 0, false }))
+}
+
+func TestCycle(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([]string{"a", "b", "a", "b", "a"}, Cycle(5, []string{"a", "b"}))
+	req.Equal([]string{"a", "b"}, Cycle(2, []string{"a", "b", "c"}))
+	req.Empty(Cycle(0, []string{"a"}))
+	req.Empty(Cycle(3, []string{}))
+	req.Empty(Cycle[string](3, nil))
+}
+
+func TestRotate(t *testing.T) {
+	req := require.New(t)
+
+	l := []int{1, 2, 3, 4, 5}
+	req.Equal([]int{3, 4, 5, 1, 2}, Rotate(2, l))
+	req.Equal([]int{4, 5, 1, 2, 3}, Rotate(-2, l))
+	req.Equal([]int{2, 3, 4, 5, 1}, Rotate(11, l))
+	req.Equal(l, Rotate(0, l))
+	req.Equal([]int{}, Rotate(3, []int{}))
+	req.Nil(Rotate[int](3, nil))
+}
+
+func TestShift(t *testing.T) {
+	req := require.New(t)
+
+	head, tail := Shift([]int{1, 2, 3})
+	req.Equal(maybe.Unit(1), head)
+	req.Equal([]int{2, 3}, tail)
+
+	head, tail = Shift[int](nil)
+	req.Equal(maybe.Nothing[int](), head)
+	req.Empty(tail)
+}
+
+func TestForEach(t *testing.T) {
+	req := require.New(t)
+
+	var seen []int
+	err := ForEach(func(x int) error {
+		seen = append(seen, x)
+		if x == 2 {
+			return errors.ErrUnsupported
+		}
+		return nil
+	}, []int{1, 2, 3})
+	req.ErrorIs(err, errors.ErrUnsupported)
+	req.Equal("unsupported operation index=1", err.Error())
+	req.Equal([]int{1, 2}, seen)
+
+	sum := 0
+	req.NoError(ForEachIndex(func(i, x int) error {
+		sum += i * x
+		return nil
+	}, []int{1, 2, 3}))
+	req.Equal(8, sum)
+}
+
+func TestIntersect(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([]int{3, 1}, Intersect([]int{3, 1, 2, 3, 1}, []int{1, 3, 5}))
+	req.Nil(Intersect([]int{1}, nil))
+	req.Equal([]int{2, 2}, Difference([]int{3, 2, 1, 2}, []int{1, 3}))
+	req.Equal([]int{1}, Difference([]int{1}, nil))
+}
+
+func TestDiff(t *testing.T) {
+	req := require.New(t)
+
+	added, removed := Diff([]int{1, 2, 3, 2}, []int{3, 4, 5, 4, 1})
+	req.Equal([]int{4, 5}, added)
+	req.Equal([]int{2}, removed)
+
+	added, removed = Diff(nil, []int{1, 1})
+	req.Equal([]int{1}, added)
+	req.Nil(removed)
+
+	added, removed = Diff[int](nil, nil)
+	req.Nil(added)
+	req.Nil(removed)
+
+	type entity struct {
+		ID   int
+		Name string
+	}
+	added2, removed2 := DiffBy(func(e entity) int { return e.ID },
+		[]entity{{1, "a"}, {2, "b"}},
+		[]entity{{2, "B"}, {3, "c"}, {3, "C"}})
+	req.Equal([]entity{{3, "c"}}, added2)
+	req.Equal([]entity{{1, "a"}}, removed2)
+}
+
+func TestTopN(t *testing.T) {
+	req := require.New(t)
+
+	l := []string{"b", "a", "c", "a", "b", "d", "a"}
+	req.Equal([]Frequency[string]{{"a", 3}, {"b", 2}}, TopN(2, l))
+	req.Equal([]Frequency[string]{{"a", 3}, {"b", 2}, {"c", 1}, {"d", 1}}, TopN(10, l))
+	req.Empty(TopN(0, l))
+	req.Empty(TopN[string](3, nil))
+}
+
+func TestCartesian(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([]tuple.Pair[int, string]{
+		tuple.MakePair(1, "a"), tuple.MakePair(1, "b"),
+		tuple.MakePair(2, "a"), tuple.MakePair(2, "b"),
+	}, Product2([]int{1, 2}, []string{"a", "b"}))
+	req.Empty(Product2([]int{1, 2}, []string{}))
+
+	req.Equal([][]int{{1, 3, 5}, {1, 4, 5}, {2, 3, 5}, {2, 4, 5}}, CartesianN([][]int{{1, 2}, {3, 4}, {5}}))
+	req.Empty(CartesianN([][]int{{1, 2}, {}}))
+	req.Empty(CartesianN[int](nil))
+}
+
+func TestKeyBy(t *testing.T) {
+	req := require.New(t)
+
+	type user struct {
+		ID   int
+		Name string
+	}
+	users := []user{{1, "a"}, {2, "b"}, {1, "c"}}
+	id := func(u user) int { return u.ID }
+
+	req.Equal(map[int]user{1: {1, "c"}, 2: {2, "b"}}, KeyBy(id, users))
+
+	m, err := KeyByUnique(id, users[:2])
+	req.NoError(err)
+	req.Equal(map[int]user{1: {1, "a"}, 2: {2, "b"}}, m)
+
+	_, err = KeyByUnique(id, users)
+	req.EqualError(err, "duplicate key key=1 index=2")
+}
+
+func TestMultiIndex(t *testing.T) {
+	req := require.New(t)
+
+	type user struct {
+		Name  string
+		Alias string
+	}
+	a, b, c := user{"alice", "al"}, user{"bob", "bob"}, user{"albert", "al"}
+	idx := MultiIndex([]func(user) string{
+		func(u user) string { return u.Name },
+		func(u user) string { return u.Alias },
+	}, []user{a, b, c})
+	req.Equal(map[string][]user{
+		"alice":  {a},
+		"al":     {a, c},
+		"bob":    {b},
+		"albert": {c},
+	}, idx)
+	req.Empty(MultiIndex[user, string](nil, []user{a}))
+	req.Empty(MultiIndex([]func(user) string{func(u user) string { return u.Name }}, nil))
+}
+
+func TestSpan(t *testing.T) {
+	req := require.New(t)
+
+	small := func(x int) bool { return x < 3 }
+	prefix, rest := Span(small, []int{1, 2, 3, 1})
+	req.Equal([]int{1, 2}, prefix)
+	req.Equal([]int{3, 1}, rest)
+
+	prefix, rest = Break(small, []int{3, 4, 1, 5})
+	req.Equal([]int{3, 4}, prefix)
+	req.Equal([]int{1, 5}, rest)
+
+	prefix, rest = Span(small, nil)
+	req.Nil(prefix)
+	req.Nil(rest)
+}
+
+func TestChunkBy(t *testing.T) {
+	req := require.New(t)
+
+	even := func(x int) bool { return x%2 == 0 }
+	req.Equal([][]int{{1, 3}, {2, 4}, {5}, {6}}, ChunkBy(even, []int{1, 3, 2, 4, 5, 6}))
+	req.Equal([][]int{}, ChunkBy(even, []int{}))
+	req.Nil(ChunkBy(even, nil))
+}
+
+func TestRunsBy(t *testing.T) {
+	req := require.New(t)
+
+	even := func(x int) bool { return x%2 == 0 }
+	req.Equal([]Run[bool, int]{
+		{Key: false, Items: []int{1, 3}},
+		{Key: true, Items: []int{2, 4}},
+		{Key: false, Items: []int{5}},
+	}, RunsBy(even, []int{1, 3, 2, 4, 5}))
+	req.Equal([]Run[bool, int]{}, RunsBy(even, []int{}))
+	req.Nil(RunsBy(even, nil))
+}
+
+func TestTranspose(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([][]int{{1, 4}, {2, 5}, {3, 6}}, Transpose([][]int{{1, 2, 3}, {4, 5, 6}}))
+	req.Equal([][]int{{1, 3, 4}, {2, 0, 5}, {0, 0, 6}}, Transpose([][]int{{1, 2}, {3}, {4, 5, 6}}))
+	req.Equal([][]int{}, Transpose[int](nil))
+}
+
+func TestCombinations(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}, Combinations([]int{1, 2, 3, 4}, 2))
+	req.Equal([][]int{{1, 2, 3}}, Combinations([]int{1, 2, 3}, 3))
+	req.Equal([][]int{{1}, {2}}, Combinations([]int{1, 2}, 1))
+	req.Len(Combinations(make([]int, 10), 4), 210)
+	req.Empty(Combinations([]int{1, 2}, 0))
+	req.Empty(Combinations([]int{1, 2}, 3))
+	req.Empty(Combinations[int](nil, 1))
+}
+
+func TestPairs(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([]tuple.Pair[string, string]{
+		tuple.MakePair("a", "b"),
+		tuple.MakePair("a", "c"),
+		tuple.MakePair("b", "c"),
+	}, Pairs([]string{"a", "b", "c"}))
+	req.Empty(Pairs([]string{"a"}))
+	req.Empty(Pairs[string](nil))
+}
@@ -0,0 +1,36 @@
+// Package tuple provides generic product types.
+package tuple
+
+// Pair is a pair of values.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// MakePair creates a pair.
+func MakePair[A, B any](first A, second B) Pair[A, B] {
+	return Pair[A, B]{First: first, Second: second}
+}
+
+// Swap returns the pair with its components swapped.
+func (p Pair[A, B]) Swap() Pair[B, A] {
+	return Pair[B, A]{First: p.Second, Second: p.First}
+}
+
+// Map applies a function to each component of a pair.
+// It's a function rather than a method since methods can't introduce type parameters.
+func Map[A, B, C, D any](f func(A) C, g func(B) D, p Pair[A, B]) Pair[C, D] {
+	return Pair[C, D]{First: f(p.First), Second: g(p.Second)}
+}
+
+// Triple is a triple of values.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// MakeTriple creates a triple.
+func MakeTriple[A, B, C any](first A, second B, third C) Triple[A, B, C] {
+	return Triple[A, B, C]{First: first, Second: second, Third: third}
+}
@@ -0,0 +1,24 @@
+package tuple
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPair(t *testing.T) {
+	req := require.New(t)
+
+	p := MakePair(1234, "abcd")
+	req.Equal(Pair[int, string]{1234, "abcd"}, p)
+	req.Equal(MakePair("abcd", 1234), p.Swap())
+	req.Equal(MakePair("1234", "ABCD"), Map(strconv.Itoa, strings.ToUpper, p))
+}
+
+func TestTriple(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(Triple[int, string, bool]{1234, "abcd", true}, MakeTriple(1234, "abcd", true))
+}